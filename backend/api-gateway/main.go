@@ -1,19 +1,25 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/go-redis/redis/v8"
 	"github.com/joho/godotenv"
+	"multimodal-platform/shared/ratelimit"
 	"multimodal-platform/shared/utils"
 )
 
+var limiter *ratelimit.Limiter
+
 func main() {
 	// Load environment variables
 	if err := godotenv.Load(); err != nil {
@@ -25,6 +31,13 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
+	redisAddr := os.Getenv("REDIS_ADDR")
+	if redisAddr == "" {
+		redisAddr = "localhost:6379"
+	}
+	limiter = ratelimit.New(redis.NewClient(&redis.Options{Addr: redisAddr}))
+	initUpstreams()
+
 	r := gin.Default()
 
 	// Middleware
@@ -121,13 +134,45 @@ func requestLoggerMiddleware() gin.HandlerFunc {
 	})
 }
 
+// rateLimitMiddleware caps requests per client IP + route, backed by Redis
+// so the limit holds across every gateway instance. Override the default
+// with GATEWAY_RATE_LIMIT_PER_MIN.
 func rateLimitMiddleware() gin.HandlerFunc {
-	// TODO: Implement rate limiting using Redis
+	limit := envInt("GATEWAY_RATE_LIMIT_PER_MIN", 300)
+	window := time.Minute
+
 	return func(c *gin.Context) {
+		key := c.ClientIP() + ":" + c.FullPath()
+
+		allowed, retryAfter, err := limiter.Allow(c.Request.Context(), "gateway", key, limit, window)
+		if err != nil {
+			log.Printf("Rate limiter error: %v", err)
+		}
+		if !allowed {
+			c.Header("Retry-After", strconv.Itoa(int(retryAfter.Seconds())))
+			utils.TooManyRequests(c, "Rate limit exceeded")
+			c.Abort()
+			return
+		}
+
 		c.Next()
 	}
 }
 
+// envInt reads an integer environment variable, falling back to def when
+// unset or unparsable.
+func envInt(key string, def int) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
 func authMiddleware() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		authHeader := c.GetHeader("Authorization")
@@ -168,37 +213,33 @@ func healthCheck(c *gin.Context) {
 }
 
 func readinessCheck(c *gin.Context) {
-	// TODO: Check if downstream services are available
-	utils.Success(c, gin.H{
-		"status":     "ready",
-		"timestamp":  time.Now().Unix(),
-		"services": gin.H{
-			"auth-service":      "healthy",
-			"user-service":      "healthy",
-			"game-service":      "healthy",
-			"websocket-service": "healthy",
-		},
-	})
-}
-
-// Proxy functions to downstream services
-func proxyToAuthService(c *gin.Context) {
-	// TODO: Implement HTTP proxy to auth-service
-	// For now, return a placeholder
-	utils.InternalError(c, "Auth service not implemented yet")
-}
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 3*time.Second)
+	defer cancel()
+
+	services := gin.H{
+		"auth-service":      probeStatus(ctx, authUpstream),
+		"user-service":      probeStatus(ctx, userUpstream),
+		"game-service":      probeStatus(ctx, gameUpstream),
+		"websocket-service": probeStatus(ctx, websocketUpstream),
+	}
 
-func proxyToUserService(c *gin.Context) {
-	// TODO: Implement HTTP proxy to user-service
-	utils.InternalError(c, "User service not implemented yet")
-}
+	status := "ready"
+	for _, s := range services {
+		if s != "healthy" {
+			status = "degraded"
+		}
+	}
 
-func proxyToGameService(c *gin.Context) {
-	// TODO: Implement HTTP proxy to game-service
-	utils.InternalError(c, "Game service not implemented yet")
+	utils.Success(c, gin.H{
+		"status":    status,
+		"timestamp": time.Now().Unix(),
+		"services":  services,
+	})
 }
 
-func proxyWebSocket(c *gin.Context) {
-	// TODO: Implement WebSocket proxy to websocket-service
-	utils.InternalError(c, "WebSocket service not implemented yet")
+func probeStatus(ctx context.Context, u *upstream) string {
+	if u.healthy(ctx) {
+		return "healthy"
+	}
+	return "unhealthy"
 }
\ No newline at end of file
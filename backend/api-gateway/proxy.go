@@ -0,0 +1,254 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sony/gobreaker"
+	"multimodal-platform/shared/utils"
+)
+
+const maxProxyAttempts = 3
+
+var (
+	authUpstream      *upstream
+	userUpstream      *upstream
+	gameUpstream      *upstream
+	websocketUpstream *upstream
+)
+
+// upstream proxies requests to one downstream service. Failures are
+// retried with backoff and tracked by a circuit breaker so a service that's
+// actually down gets failed fast instead of piling up timeouts.
+type upstream struct {
+	name    string
+	baseURL *url.URL
+	client  *http.Client
+	breaker *gobreaker.CircuitBreaker
+}
+
+// proxyResponse buffers a downstream response so attemptWithRetry can
+// inspect the status code before committing anything to the client.
+type proxyResponse struct {
+	statusCode int
+	header     http.Header
+	body       []byte
+}
+
+func initUpstreams() {
+	authUpstream = newUpstream("auth-service", "AUTH_SERVICE_URL", "http://localhost:8082")
+	userUpstream = newUpstream("user-service", "USER_SERVICE_URL", "http://localhost:8083")
+	gameUpstream = newUpstream("game-service", "GAME_SERVICE_URL", "http://localhost:8084")
+	websocketUpstream = newUpstream("websocket-service", "WEBSOCKET_SERVICE_URL", "http://localhost:8081")
+}
+
+func newUpstream(name, envVar, fallback string) *upstream {
+	raw := os.Getenv(envVar)
+	if raw == "" {
+		raw = fallback
+	}
+	parsed, err := url.Parse(raw)
+	if err != nil {
+		log.Fatalf("Invalid %s: %v", envVar, err)
+	}
+
+	return &upstream{
+		name:    name,
+		baseURL: parsed,
+		client:  &http.Client{Timeout: 10 * time.Second},
+		breaker: gobreaker.NewCircuitBreaker(gobreaker.Settings{
+			Name:        name,
+			MaxRequests: 1,
+			Interval:    30 * time.Second,
+			Timeout:     15 * time.Second,
+			ReadyToTrip: func(counts gobreaker.Counts) bool {
+				return counts.ConsecutiveFailures >= 5
+			},
+		}),
+	}
+}
+
+// healthy probes the upstream's own /health endpoint.
+func (u *upstream) healthy(ctx context.Context) bool {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.baseURL.String()+"/health", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return false
+	}
+	defer resp.Body.Close()
+	return resp.StatusCode == http.StatusOK
+}
+
+// forward proxies c's request to the upstream, retrying on connection
+// errors or 5xx responses and tripping the circuit breaker on repeated
+// failure, then writes the (buffered) downstream response back to c.
+func (u *upstream) forward(c *gin.Context) {
+	bodyBytes, err := io.ReadAll(c.Request.Body)
+	if err != nil {
+		utils.InternalError(c, "Failed to read request body")
+		return
+	}
+
+	result, err := u.breaker.Execute(func() (interface{}, error) {
+		return u.attemptWithRetry(c, bodyBytes)
+	})
+	if err != nil {
+		if errors.Is(err, gobreaker.ErrOpenState) || errors.Is(err, gobreaker.ErrTooManyRequests) {
+			utils.InternalError(c, fmt.Sprintf("%s is temporarily unavailable", u.name))
+			return
+		}
+		utils.InternalError(c, fmt.Sprintf("%s request failed", u.name), err.Error())
+		return
+	}
+
+	resp := result.(*proxyResponse)
+	for key, values := range resp.header {
+		for _, v := range values {
+			c.Writer.Header().Add(key, v)
+		}
+	}
+	c.Writer.WriteHeader(resp.statusCode)
+	c.Writer.Write(resp.body)
+}
+
+func (u *upstream) attemptWithRetry(c *gin.Context, bodyBytes []byte) (*proxyResponse, error) {
+	var lastErr error
+	backoff := 100 * time.Millisecond
+
+	for attempt := 0; attempt < maxProxyAttempts; attempt++ {
+		resp, err := u.doOnce(c, bodyBytes)
+		if err == nil && resp.statusCode < http.StatusInternalServerError {
+			return resp, nil
+		}
+
+		if err != nil {
+			lastErr = err
+		} else {
+			lastErr = fmt.Errorf("%s responded %d", u.name, resp.statusCode)
+		}
+
+		if attempt < maxProxyAttempts-1 {
+			time.Sleep(backoff)
+			backoff *= 2
+		}
+	}
+	return nil, lastErr
+}
+
+func (u *upstream) doOnce(c *gin.Context, bodyBytes []byte) (*proxyResponse, error) {
+	target := *u.baseURL
+	target.Path = singleJoiningSlash(u.baseURL.Path, c.Request.URL.Path)
+	target.RawQuery = c.Request.URL.RawQuery
+
+	req, err := http.NewRequestWithContext(c.Request.Context(), c.Request.Method, target.String(), bytes.NewReader(bodyBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header = c.Request.Header.Clone()
+
+	// Forward the identity authMiddleware already verified instead of
+	// making the downstream service re-derive it from the raw token.
+	if userID, ok := c.Get("user_id"); ok {
+		req.Header.Set("X-User-ID", fmt.Sprintf("%v", userID))
+	}
+	if username, ok := c.Get("username"); ok {
+		req.Header.Set("X-Username", fmt.Sprintf("%v", username))
+	}
+
+	resp, err := u.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	return &proxyResponse{statusCode: resp.StatusCode, header: resp.Header, body: body}, nil
+}
+
+// singleJoiningSlash mirrors the helper httputil.NewSingleHostReverseProxy
+// uses internally to join a base path with a request path without doubling
+// or dropping the slash between them.
+func singleJoiningSlash(a, b string) string {
+	aslash := strings.HasSuffix(a, "/")
+	bslash := strings.HasPrefix(b, "/")
+	switch {
+	case aslash && bslash:
+		return a + b[1:]
+	case !aslash && !bslash:
+		return a + "/" + b
+	}
+	return a + b
+}
+
+// proxyToAuthService forwards auth requests to the auth service.
+func proxyToAuthService(c *gin.Context) {
+	authUpstream.forward(c)
+}
+
+// proxyToUserService forwards user requests to the user service.
+func proxyToUserService(c *gin.Context) {
+	userUpstream.forward(c)
+}
+
+// proxyToGameService forwards room/game requests to the game service.
+func proxyToGameService(c *gin.Context) {
+	gameUpstream.forward(c)
+}
+
+// proxyWebSocket hijacks the client connection and pipes it directly to the
+// websocket-service's TCP connection, re-serializing the original HTTP
+// handshake with http.Request.Write so its headers (Sec-WebSocket-*
+// included) reach gorilla's upgrader on the other end.
+func proxyWebSocket(c *gin.Context) {
+	upstreamConn, err := net.Dial("tcp", websocketUpstream.baseURL.Host)
+	if err != nil {
+		utils.InternalError(c, "websocket-service unavailable")
+		return
+	}
+	defer upstreamConn.Close()
+
+	if err := c.Request.Write(upstreamConn); err != nil {
+		utils.InternalError(c, "Failed to forward websocket handshake")
+		return
+	}
+
+	hijacker, ok := c.Writer.(http.Hijacker)
+	if !ok {
+		utils.InternalError(c, "Connection does not support hijacking")
+		return
+	}
+	clientConn, _, err := hijacker.Hijack()
+	if err != nil {
+		utils.InternalError(c, "Failed to hijack connection")
+		return
+	}
+	defer clientConn.Close()
+
+	done := make(chan struct{}, 2)
+	go pipe(done, upstreamConn, clientConn)
+	go pipe(done, clientConn, upstreamConn)
+	<-done
+}
+
+func pipe(done chan<- struct{}, dst io.Writer, src io.Reader) {
+	io.Copy(dst, src)
+	done <- struct{}{}
+}
@@ -0,0 +1,32 @@
+package category
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"multimodal-platform/shared/models"
+	"multimodal-platform/shared/utils"
+)
+
+// ListHandler handles GET /categories?parent_id=…&status=…, returning the
+// nested children of parent_id (the whole root forest when omitted).
+func (s *Service) ListHandler(c *gin.Context) {
+	var parentID *uuid.UUID
+	if raw := c.Query("parent_id"); raw != "" {
+		id, err := uuid.Parse(raw)
+		if err != nil {
+			utils.BadRequest(c, "Invalid parent_id")
+			return
+		}
+		parentID = &id
+	}
+
+	status := models.CategoryStatus(c.Query("status"))
+
+	tree, err := s.Tree(parentID, status)
+	if err != nil {
+		utils.InternalError(c, "Failed to load categories", err.Error())
+		return
+	}
+
+	utils.Success(c, tree)
+}
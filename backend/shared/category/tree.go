@@ -0,0 +1,76 @@
+// Package category builds nested trees out of the flat Category rows in
+// multimodal-platform/shared/models, for browsing Rooms and the question
+// bank by theme (Education > Physics > Rotation). Like its sibling
+// shared/ packages, Service has no HTTP route mounted in this repo yet -
+// no game-service exists to own GET /categories - but ListHandler is ready
+// to be wired into one.
+package category
+
+import (
+	"sort"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"multimodal-platform/shared/models"
+)
+
+// CategoryNested is one Category plus its recursively nested Children, the
+// shape GET /categories renders back.
+type CategoryNested struct {
+	models.Category
+	Children []CategoryNested `json:"children,omitempty"`
+}
+
+// Service loads Category rows and builds nested trees from them.
+type Service struct {
+	db *gorm.DB
+}
+
+// NewService returns a Service backed by db.
+func NewService(db *gorm.DB) *Service {
+	return &Service{db: db}
+}
+
+// Tree returns the nested children of parentID (nil for the root),
+// restricted to status when status is non-empty.
+func (s *Service) Tree(parentID *uuid.UUID, status models.CategoryStatus) ([]CategoryNested, error) {
+	query := s.db.Model(&models.Category{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	var flat []models.Category
+	if err := query.Order("sorter").Find(&flat).Error; err != nil {
+		return nil, err
+	}
+
+	return BuildTree(flat, parentID), nil
+}
+
+// BuildTree recursively collects flat's Categories into a CategoryNested
+// tree rooted at parentID, entirely in memory so a single query can serve
+// any subtree.
+func BuildTree(flat []models.Category, parentID *uuid.UUID) []CategoryNested {
+	var nodes []CategoryNested
+	for _, cat := range flat {
+		if !sameParent(cat.ParentID, parentID) {
+			continue
+		}
+		nodes = append(nodes, CategoryNested{
+			Category: cat,
+			Children: BuildTree(flat, &cat.ID),
+		})
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Sorter < nodes[j].Sorter })
+	return nodes
+}
+
+// sameParent reports whether two *uuid.UUID parent references point at the
+// same category (or are both nil, i.e. both root).
+func sameParent(a, b *uuid.UUID) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+	return *a == *b
+}
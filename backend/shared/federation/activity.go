@@ -0,0 +1,91 @@
+package federation
+
+import (
+	"fmt"
+
+	"github.com/google/uuid"
+	"multimodal-platform/shared/models"
+)
+
+// Activity is a generic ActivityPub activity envelope. Object holds the
+// activity-specific payload (a GameResultObject, a bare actor URI for
+// Follow/Undo, etc.) so one struct covers every activity type this package
+// sends or receives.
+type Activity struct {
+	Context   string      `json:"@context"`
+	ID        string      `json:"id"`
+	Type      string      `json:"type"`
+	Actor     string      `json:"actor"`
+	Object    interface{} `json:"object"`
+	Published string      `json:"published,omitempty"`
+	To        []string    `json:"to,omitempty"`
+}
+
+// GameResultObject is the Note-like object a Create/Announce activity
+// wraps around a finished GameSession, high score, or tournament win.
+type GameResultObject struct {
+	ID        string `json:"id"`
+	Type      string `json:"type"`
+	AttributedTo string `json:"attributedTo"`
+	Content   string `json:"content"`
+	Summary   string `json:"summary,omitempty"`
+}
+
+// NewGameSessionCreateActivity announces that actorURI's User finished a
+// GameSession, as a Create activity wrapping a GameResultObject.
+func NewGameSessionCreateActivity(baseURL, actorURI string, session models.GameSession, score models.PlayerScore) Activity {
+	objectID := fmt.Sprintf("%s/game-sessions/%s/results/%s", baseURL, session.ID, score.UserID)
+	return Activity{
+		Context: activityStreamsContext,
+		ID:      objectID + "/activity",
+		Type:    "Create",
+		Actor:   actorURI,
+		To:      []string{actorURI + "/followers"},
+		Object: GameResultObject{
+			ID:           objectID,
+			Type:         "Note",
+			AttributedTo: actorURI,
+			Content:      fmt.Sprintf("Finished %s with a score of %d.", session.GameType, score.Score),
+		},
+	}
+}
+
+// NewHighScoreAnnounceActivity announces a new personal or leaderboard-
+// topping high score as an Announce activity.
+func NewHighScoreAnnounceActivity(baseURL, actorURI string, score models.PlayerScore, gameType models.GameType) Activity {
+	objectID := fmt.Sprintf("%s/scores/%s", baseURL, uuid.New())
+	return Activity{
+		Context: activityStreamsContext,
+		ID:      objectID + "/activity",
+		Type:    "Announce",
+		Actor:   actorURI,
+		To:      []string{actorURI + "/followers"},
+		Object: GameResultObject{
+			ID:           objectID,
+			Type:         "Note",
+			AttributedTo: actorURI,
+			Summary:      "New high score",
+			Content:      fmt.Sprintf("New high score in %s: %d points.", gameType, score.Score),
+		},
+	}
+}
+
+// NewTournamentWinAnnounceActivity announces that actorURI's User won a
+// Tournament.
+func NewTournamentWinAnnounceActivity(baseURL, actorURI string, tournament models.Tournament) Activity {
+	objectID := fmt.Sprintf("%s/tournaments/%s/result", baseURL, tournament.ID)
+	return Activity{
+		Context: activityStreamsContext,
+		ID:      objectID + "/activity",
+		Type:    "Announce",
+		Actor:   actorURI,
+		To:      []string{actorURI + "/followers"},
+		Object: GameResultObject{
+			ID:           objectID,
+			Type:         "Note",
+			AttributedTo: actorURI,
+			Summary:      "Tournament win",
+			Content:      fmt.Sprintf("Won the %q tournament.", tournament.Name),
+		},
+	}
+}
@@ -0,0 +1,73 @@
+// Package federation exposes local Users as ActivityPub actors and
+// publishes GameSession completions, high scores, and tournament wins to
+// their followers. It has no HTTP routes mounted in this repo yet — no
+// game-service exists to own the /users/:id/actor, /inbox and
+// /.well-known/webfinger endpoints — but every handler here is written to
+// be dropped straight into one.
+package federation
+
+import (
+	"fmt"
+
+	"multimodal-platform/shared/models"
+)
+
+const activityStreamsContext = "https://www.w3.org/ns/activitystreams"
+
+// Actor is the JSON-LD rendering of a local User as an ActivityPub actor.
+// It is produced by ActorFromUser rather than added as fields on User, so
+// User's own GORM/JSON tags stay untouched by federation concerns.
+type Actor struct {
+	Context           string      `json:"@context"`
+	ID                string      `json:"id"`
+	Type              string      `json:"type"`
+	PreferredUsername string      `json:"preferredUsername"`
+	Name              string      `json:"name"`
+	Icon              *ActorImage `json:"icon,omitempty"`
+	Inbox             string      `json:"inbox"`
+	Outbox            string      `json:"outbox"`
+	Followers         string      `json:"followers"`
+	PublicKey         PublicKey   `json:"publicKey"`
+}
+
+// ActorImage is an ActivityPub Image object, used here for a User's avatar.
+type ActorImage struct {
+	Type      string `json:"type"`
+	MediaType string `json:"mediaType,omitempty"`
+	URL       string `json:"url"`
+}
+
+// PublicKey is the embedded RSA public key every actor publishes so remote
+// instances can verify our HTTP Signatures.
+type PublicKey struct {
+	ID           string `json:"id"`
+	Owner        string `json:"owner"`
+	PublicKeyPEM string `json:"publicKeyPem"`
+}
+
+// ActorFromUser renders user as an ActivityPub Person actor rooted at
+// baseURL (e.g. "https://games.example.com"), embedding keyPEM as its
+// public key.
+func ActorFromUser(user models.User, baseURL, keyPEM string) Actor {
+	actorID := fmt.Sprintf("%s/users/%s", baseURL, user.ID)
+
+	actor := Actor{
+		Context:           activityStreamsContext,
+		ID:                actorID,
+		Type:              "Person",
+		PreferredUsername: user.Username,
+		Name:              user.Username,
+		Inbox:             actorID + "/inbox",
+		Outbox:            actorID + "/outbox",
+		Followers:         actorID + "/followers",
+		PublicKey: PublicKey{
+			ID:           actorID + "#main-key",
+			Owner:        actorID,
+			PublicKeyPEM: keyPEM,
+		},
+	}
+	if user.AvatarURL != nil {
+		actor.Icon = &ActorImage{Type: "Image", URL: *user.AvatarURL}
+	}
+	return actor
+}
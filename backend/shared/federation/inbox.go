@@ -0,0 +1,89 @@
+package federation
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"multimodal-platform/shared/models"
+	"multimodal-platform/shared/utils"
+)
+
+// inboxActivity is the subset of an incoming Activity's fields the inbox
+// needs to route it; the object can be a bare actor URI (Follow/Undo) or a
+// richer payload (Like), so it's left as interface{}.
+type inboxActivity struct {
+	Type   string      `json:"type"`
+	Actor  string      `json:"actor"`
+	Object interface{} `json:"object"`
+}
+
+// InboxHandler accepts Follow, Undo, and Like activities addressed to a
+// local User's actor. Every request's HTTP Signature must verify against
+// the sending actor's published public key before it's processed.
+func InboxHandler(db *gorm.DB) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := c.Param("id")
+
+		body, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			utils.BadRequest(c, "Failed to read request body")
+			return
+		}
+
+		var activity inboxActivity
+		if err := json.Unmarshal(body, &activity); err != nil {
+			utils.BadRequest(c, "Invalid activity payload")
+			return
+		}
+
+		publicKeyPEM, err := fetchActorPublicKey(c.Request.Context(), activity.Actor)
+		if err != nil {
+			utils.BadRequest(c, "Could not resolve sending actor's public key")
+			return
+		}
+		if err := VerifySignature(c.Request, publicKeyPEM, body); err != nil {
+			utils.Unauthorized(c, "Invalid HTTP signature")
+			return
+		}
+
+		switch activity.Type {
+		case "Follow":
+			handleFollow(c, db, userID, activity)
+		case "Undo":
+			handleUndo(c, db, userID, activity)
+		case "Like":
+			// Likes on score objects are accepted and discarded for now;
+			// there is no scores-feed to attach reaction counts to yet.
+			c.JSON(http.StatusAccepted, gin.H{})
+		default:
+			utils.BadRequest(c, "Unsupported activity type")
+		}
+	}
+}
+
+func handleFollow(c *gin.Context, db *gorm.DB, userID string, activity inboxActivity) {
+	follower := models.Follower{
+		UserID:   uuidOrZero(userID),
+		ActorURI: activity.Actor,
+		InboxURL: activity.Actor + "/inbox",
+		Status:   models.FollowStatusAccepted,
+	}
+	if err := db.Create(&follower).Error; err != nil {
+		utils.InternalError(c, "Failed to record follower", err.Error())
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{})
+}
+
+func handleUndo(c *gin.Context, db *gorm.DB, userID string, activity inboxActivity) {
+	err := db.Where("user_id = ? AND actor_uri = ?", userID, activity.Actor).
+		Delete(&models.Follower{}).Error
+	if err != nil {
+		utils.InternalError(c, "Failed to remove follower", err.Error())
+		return
+	}
+	c.JSON(http.StatusAccepted, gin.H{})
+}
@@ -0,0 +1,151 @@
+package federation
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"multimodal-platform/shared/models"
+)
+
+// maxDeliveryAttempts caps retries before a DeliveryJob is given up on and
+// marked failed.
+const maxDeliveryAttempts = 5
+
+// DeliveryQueue persists outbound activities as DeliveryJob rows and
+// delivers them to remote inboxes with exponential backoff, so a delivery
+// failure survives a process restart instead of being lost.
+type DeliveryQueue struct {
+	db     *gorm.DB
+	client *http.Client
+}
+
+// NewDeliveryQueue returns a DeliveryQueue backed by db.
+func NewDeliveryQueue(db *gorm.DB) *DeliveryQueue {
+	return &DeliveryQueue{db: db, client: &http.Client{Timeout: 10 * time.Second}}
+}
+
+// Enqueue queues activity for delivery to inboxURL on behalf of actorID. It
+// is stored unsigned; signAndSend signs it at each delivery attempt.
+func (q *DeliveryQueue) Enqueue(actorID uuid.UUID, inboxURL string, activity Activity) error {
+	payload, err := json.Marshal(activity)
+	if err != nil {
+		return err
+	}
+
+	job := models.DeliveryJob{
+		UserID:        actorID,
+		InboxURL:      inboxURL,
+		ActivityJSON:  string(payload),
+		Status:        models.DeliveryStatusPending,
+		NextAttemptAt: time.Now(),
+	}
+	return q.db.Create(&job).Error
+}
+
+// Run polls for due DeliveryJobs every pollInterval and attempts delivery
+// until ctx is cancelled.
+func (q *DeliveryQueue) Run(ctx context.Context, pollInterval time.Duration) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			q.deliverDueJobs(ctx)
+		}
+	}
+}
+
+func (q *DeliveryQueue) deliverDueJobs(ctx context.Context) {
+	var jobs []models.DeliveryJob
+	err := q.db.Where("status = ? AND next_attempt_at <= ?", models.DeliveryStatusPending, time.Now()).
+		Find(&jobs).Error
+	if err != nil {
+		log.Printf("federation: failed to load due delivery jobs: %v", err)
+		return
+	}
+
+	for _, job := range jobs {
+		q.attempt(ctx, job)
+	}
+}
+
+func (q *DeliveryQueue) attempt(ctx context.Context, job models.DeliveryJob) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, job.InboxURL, bytes.NewReader([]byte(job.ActivityJSON)))
+	if err == nil {
+		req.Header.Set("Content-Type", "application/activity+json")
+		err = q.signAndSend(req, job)
+	}
+
+	job.Attempts++
+	if err != nil {
+		job.LastError = err.Error()
+		if job.Attempts >= maxDeliveryAttempts {
+			job.Status = models.DeliveryStatusFailed
+		} else {
+			job.NextAttemptAt = time.Now().Add(backoff(job.Attempts))
+		}
+		q.db.Save(&job)
+		return
+	}
+
+	job.Status = models.DeliveryStatusDelivered
+	q.db.Save(&job)
+}
+
+// signAndSend signs req with job.UserID's ActorKeyPair and sends it,
+// returning an error for both non-2xx responses and transport failures.
+func (q *DeliveryQueue) signAndSend(req *http.Request, job models.DeliveryJob) error {
+	var keyPair models.ActorKeyPair
+	if err := q.db.First(&keyPair, "user_id = ?", job.UserID).Error; err != nil {
+		return err
+	}
+
+	// The keyId must name the key our actor document publishes
+	// (ActorFromUser: <actor URI>#main-key), not a URL on the remote inbox's host.
+	var activity Activity
+	if err := json.Unmarshal([]byte(job.ActivityJSON), &activity); err != nil {
+		return err
+	}
+	keyID := activity.Actor + "#main-key"
+	if err := SignRequest(req, keyID, keyPair.PrivateKeyPEM, []byte(job.ActivityJSON)); err != nil {
+		return err
+	}
+
+	resp, err := q.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return &deliveryError{status: resp.StatusCode}
+	}
+	return nil
+}
+
+// backoff returns an exponential delay (capped at 1 hour) for the given
+// attempt count.
+func backoff(attempts int) time.Duration {
+	delay := time.Minute * time.Duration(1<<uint(attempts-1))
+	if delay > time.Hour {
+		delay = time.Hour
+	}
+	return delay
+}
+
+type deliveryError struct {
+	status int
+}
+
+func (e *deliveryError) Error() string {
+	return fmt.Sprintf("federation: inbox POST returned status %d", e.status)
+}
@@ -0,0 +1,72 @@
+package federation
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"gorm.io/gorm"
+	"multimodal-platform/shared/models"
+	"multimodal-platform/shared/utils"
+)
+
+// WebfingerResource is the JRD document served from
+// /.well-known/webfinger?resource=acct:username@domain, per RFC 7033.
+type WebfingerResource struct {
+	Subject string           `json:"subject"`
+	Links   []WebfingerLink  `json:"links"`
+}
+
+// WebfingerLink points a WebFinger lookup at the matching actor document.
+type WebfingerLink struct {
+	Rel  string `json:"rel"`
+	Type string `json:"type"`
+	Href string `json:"href"`
+}
+
+// WebfingerHandler resolves acct:username@domain to the matching User's
+// ActivityPub actor document, so remote instances can discover local users
+// by handle alone.
+func WebfingerHandler(db *gorm.DB, baseURL string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		resource := c.Query("resource")
+		username, ok := parseAcct(resource)
+		if !ok {
+			utils.BadRequest(c, "Invalid or missing resource parameter")
+			return
+		}
+
+		var user models.User
+		if err := db.Where("username = ?", username).First(&user).Error; err != nil {
+			utils.NotFound(c, "User not found")
+			return
+		}
+
+		actorURL := fmt.Sprintf("%s/users/%s", baseURL, user.ID)
+		// RFC 7033 requires the JRD itself as the top-level response body,
+		// not wrapped in our usual {"success":true,"data":...} envelope --
+		// real ActivityPub clients (Mastodon et al.) won't unwrap it.
+		c.Header("Content-Type", "application/jrd+json")
+		c.JSON(http.StatusOK, WebfingerResource{
+			Subject: resource,
+			Links: []WebfingerLink{
+				{Rel: "self", Type: "application/activity+json", Href: actorURL},
+			},
+		})
+	}
+}
+
+// parseAcct extracts the username from an "acct:username@domain" resource
+// parameter.
+func parseAcct(resource string) (string, bool) {
+	rest := strings.TrimPrefix(resource, "acct:")
+	if rest == resource {
+		return "", false
+	}
+	username, _, found := strings.Cut(rest, "@")
+	if !found || username == "" {
+		return "", false
+	}
+	return username, true
+}
@@ -204,4 +204,345 @@ type RoomParticipant struct {
 	IsReady   bool      `json:"is_ready" gorm:"default:false"`
 	JoinedAt  time.Time `json:"joined_at"`
 	LeftAt    *time.Time `json:"left_at,omitempty"`
-}
\ No newline at end of file
+}
+
+// Tournament chains several GameSessions played across one or more Rooms
+// into a single-elimination or round-robin bracket.
+type Tournament struct {
+	ID           uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name         string           `json:"name" gorm:"size:100;not null"`
+	Format       TournamentFormat `json:"format" gorm:"not null"`
+	Status       TournamentStatus `json:"status" gorm:"default:'pending'"`
+	CurrentRound int              `json:"current_round" gorm:"default:0"`
+	HostID       uuid.UUID        `json:"host_id" gorm:"not null"`
+	Host         User             `json:"host" gorm:"foreignKey:HostID"`
+	CreatedAt    time.Time        `json:"created_at"`
+	UpdatedAt    time.Time        `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt   `json:"-" gorm:"index"`
+
+	// Relationships
+	Rooms        []Room                  `json:"rooms,omitempty" gorm:"many2many:tournament_rooms;"`
+	Participants []TournamentParticipant `json:"participants,omitempty" gorm:"foreignKey:TournamentID"`
+	Rounds       []TournamentRound       `json:"rounds,omitempty" gorm:"foreignKey:TournamentID"`
+}
+
+// TournamentFormat represents the bracket shape of a Tournament
+type TournamentFormat string
+
+const (
+	TournamentFormatSingleElimination TournamentFormat = "single_elimination"
+	TournamentFormatRoundRobin        TournamentFormat = "round_robin"
+)
+
+// TournamentStatus represents the current lifecycle state of a Tournament
+type TournamentStatus string
+
+const (
+	TournamentStatusPending    TournamentStatus = "pending"
+	TournamentStatusSeeding    TournamentStatus = "seeding"
+	TournamentStatusInProgress TournamentStatus = "in_progress"
+	TournamentStatusFinished   TournamentStatus = "finished"
+)
+
+// TournamentParticipant is a seeded entrant in a Tournament. CreateBracket
+// seeds it from the user IDs in its request; RoomParticipant rows are not
+// read.
+type TournamentParticipant struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TournamentID uuid.UUID  `json:"tournament_id" gorm:"not null"`
+	Tournament   Tournament `json:"tournament" gorm:"foreignKey:TournamentID"`
+	UserID       uuid.UUID  `json:"user_id" gorm:"not null"`
+	User         User       `json:"user" gorm:"foreignKey:UserID"`
+	Seed         int        `json:"seed" gorm:"not null"`
+	Eliminated   bool       `json:"eliminated" gorm:"default:false"`
+}
+
+// TournamentRound groups the TournamentMatches played at one stage of the
+// bracket (or, for round-robin, one pass through the schedule).
+type TournamentRound struct {
+	ID           uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	TournamentID uuid.UUID  `json:"tournament_id" gorm:"not null"`
+	Tournament   Tournament `json:"tournament" gorm:"foreignKey:TournamentID"`
+	RoundNumber  int        `json:"round_number" gorm:"not null"`
+	CreatedAt    time.Time  `json:"created_at"`
+
+	// Relationships
+	Matches []TournamentMatch `json:"matches,omitempty" gorm:"foreignKey:RoundID"`
+}
+
+// TournamentMatch pairs two participants (one may be a bye) in a single
+// GameSession; the winner advances once the session finishes.
+type TournamentMatch struct {
+	ID             uuid.UUID              `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	RoundID        uuid.UUID              `json:"round_id" gorm:"not null"`
+	Round          TournamentRound        `json:"round" gorm:"foreignKey:RoundID"`
+	SessionID      *uuid.UUID             `json:"session_id,omitempty"`
+	Session        *GameSession           `json:"session,omitempty" gorm:"foreignKey:SessionID"`
+	ParticipantAID *uuid.UUID             `json:"participant_a_id,omitempty"`
+	ParticipantA   *TournamentParticipant `json:"participant_a,omitempty" gorm:"foreignKey:ParticipantAID"`
+	ParticipantBID *uuid.UUID             `json:"participant_b_id,omitempty"`
+	ParticipantB   *TournamentParticipant `json:"participant_b,omitempty" gorm:"foreignKey:ParticipantBID"`
+	WinnerID       *uuid.UUID             `json:"winner_id,omitempty"`
+	Winner         *TournamentParticipant `json:"winner,omitempty" gorm:"foreignKey:WinnerID"`
+	IsBye          bool                   `json:"is_bye" gorm:"default:false"`
+	CreatedAt      time.Time              `json:"created_at"`
+	UpdatedAt      time.Time              `json:"updated_at"`
+}
+
+// PlayerRating tracks a user's Elo-style skill rating per GameType and
+// Season, updated after every GameSession of that type they play in. Season
+// is part of the primary key, not just a column, so resetting a season
+// starts a fresh row instead of overwriting the one a Leaderboard for the
+// old season still needs.
+type PlayerRating struct {
+	UserID    uuid.UUID `json:"user_id" gorm:"primaryKey"`
+	User      User      `json:"user" gorm:"foreignKey:UserID"`
+	GameType  GameType  `json:"game_type" gorm:"primaryKey"`
+	Rating    float64   `json:"rating" gorm:"default:1200"`
+	Season    int       `json:"season" gorm:"primaryKey;default:1"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// RatingChange records a single Elo update to a PlayerRating so profiles can
+// render a rating-over-time graph.
+type RatingChange struct {
+	ID        uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID   `json:"user_id" gorm:"not null"`
+	User      User        `json:"user" gorm:"foreignKey:UserID"`
+	GameType  GameType    `json:"game_type" gorm:"not null"`
+	SessionID uuid.UUID   `json:"session_id" gorm:"not null"`
+	Session   GameSession `json:"session" gorm:"foreignKey:SessionID"`
+	OldRating float64     `json:"old_rating"`
+	NewRating float64     `json:"new_rating"`
+	Delta     float64     `json:"delta"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// ActorKeyPair holds the RSA keypair a User's ActivityPub actor signs
+// outbound activities with. Kept separate from User so federation concerns
+// never touch the User model's own GORM/JSON tags.
+type ActorKeyPair struct {
+	UserID        uuid.UUID `json:"user_id" gorm:"primaryKey"`
+	User          User      `json:"-" gorm:"foreignKey:UserID"`
+	PublicKeyPEM  string    `json:"public_key_pem" gorm:"type:text;not null"`
+	PrivateKeyPEM string    `json:"-" gorm:"type:text;not null"`
+	CreatedAt     time.Time `json:"created_at"`
+}
+
+// Follower is a remote ActivityPub actor following a local User's actor.
+type Follower struct {
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID    uuid.UUID      `json:"user_id" gorm:"not null;index"`
+	User      User           `json:"-" gorm:"foreignKey:UserID"`
+	ActorURI  string         `json:"actor_uri" gorm:"not null;index"`
+	InboxURL  string         `json:"inbox_url" gorm:"not null"`
+	Status    FollowStatus   `json:"status" gorm:"default:'pending'"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+}
+
+// FollowStatus represents the state of a Follow request from a remote actor
+type FollowStatus string
+
+const (
+	FollowStatusPending  FollowStatus = "pending"
+	FollowStatusAccepted FollowStatus = "accepted"
+)
+
+// DeliveryJob is one outbound activity queued for delivery to a remote
+// inbox, retried with backoff until it succeeds or exhausts its attempts.
+type DeliveryJob struct {
+	ID            uuid.UUID        `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	UserID        uuid.UUID        `json:"user_id" gorm:"not null"`
+	User          User             `json:"-" gorm:"foreignKey:UserID"`
+	InboxURL      string           `json:"inbox_url" gorm:"not null"`
+	ActivityJSON  string           `json:"activity_json" gorm:"type:text;not null"`
+	Status        DeliveryStatus   `json:"status" gorm:"default:'pending'"`
+	Attempts      int              `json:"attempts" gorm:"default:0"`
+	NextAttemptAt time.Time        `json:"next_attempt_at"`
+	LastError     string           `json:"last_error,omitempty"`
+	CreatedAt     time.Time        `json:"created_at"`
+	UpdatedAt     time.Time        `json:"updated_at"`
+}
+
+// DeliveryStatus represents a DeliveryJob's progress through the retry queue
+type DeliveryStatus string
+
+const (
+	DeliveryStatusPending   DeliveryStatus = "pending"
+	DeliveryStatusDelivered DeliveryStatus = "delivered"
+	DeliveryStatusFailed    DeliveryStatus = "failed"
+)
+
+// SpectatorSession tracks one non-player watching a live GameSession, so
+// viewer counts and replay access can be audited after the fact.
+type SpectatorSession struct {
+	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SessionID uuid.UUID  `json:"session_id" gorm:"not null;index"`
+	Session   GameSession `json:"session" gorm:"foreignKey:SessionID"`
+	UserID    *uuid.UUID `json:"user_id,omitempty"`
+	User      *User      `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	JoinedAt  time.Time  `json:"joined_at"`
+	LeftAt    *time.Time `json:"left_at,omitempty"`
+}
+
+// ChatMessage is a plain (non-overlay) message sent in a GameSession's
+// spectator chat.
+type ChatMessage struct {
+	ID        uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SessionID uuid.UUID   `json:"session_id" gorm:"not null;index"`
+	Session   GameSession `json:"session" gorm:"foreignKey:SessionID"`
+	UserID    uuid.UUID   `json:"user_id" gorm:"not null"`
+	User      User        `json:"user" gorm:"foreignKey:UserID"`
+	Text      string      `json:"text" gorm:"not null"`
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// BulletMessage is a persisted bullet-chat ("danmaku") overlay message, so
+// a replay can re-render the same scrolling overlays a live spectator saw.
+type BulletMessage struct {
+	ID        uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	SessionID uuid.UUID   `json:"session_id" gorm:"not null;index"`
+	Session   GameSession `json:"session" gorm:"foreignKey:SessionID"`
+	UserID    uuid.UUID   `json:"user_id" gorm:"not null"`
+	User      User        `json:"user" gorm:"foreignKey:UserID"`
+	Text      string      `json:"text" gorm:"not null"`
+	X         float64     `json:"x"`
+	Y         float64     `json:"y"`
+	Color     string      `json:"color"`
+	Duration  int         `json:"duration"` // milliseconds on screen
+	CreatedAt time.Time   `json:"created_at"`
+}
+
+// Visibility controls who can see and import a QuestionBank or ProblemSet.
+type Visibility string
+
+const (
+	VisibilityPrivate Visibility = "private"
+	VisibilityClass   Visibility = "class"
+	VisibilityPublic  Visibility = "public"
+)
+
+// QuestionBank is a host's named collection of ProblemSets, authored offline
+// and reused across many Rooms instead of writing one-off Questions per
+// GameSession.
+type QuestionBank struct {
+	ID         uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	Name       string         `json:"name" gorm:"size:100;not null"`
+	OwnerID    uuid.UUID      `json:"owner_id" gorm:"not null"`
+	Owner      User           `json:"owner" gorm:"foreignKey:OwnerID"`
+	Visibility Visibility     `json:"visibility" gorm:"default:'private'"`
+	CreatedAt  time.Time      `json:"created_at"`
+	UpdatedAt  time.Time      `json:"updated_at"`
+	DeletedAt  gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	ProblemSets []ProblemSet `json:"problem_sets,omitempty" gorm:"foreignKey:QuestionBankID"`
+}
+
+// ProblemSet is an ordered collection of Problems within a QuestionBank,
+// importable into any Room that can see it.
+type ProblemSet struct {
+	ID             uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	QuestionBankID uuid.UUID      `json:"question_bank_id" gorm:"not null"`
+	QuestionBank   QuestionBank   `json:"question_bank" gorm:"foreignKey:QuestionBankID"`
+	Name           string         `json:"name" gorm:"size:100;not null"`
+	Description    string         `json:"description"`
+	Visibility     Visibility     `json:"visibility" gorm:"default:'private'"`
+	CreatedAt      time.Time      `json:"created_at"`
+	UpdatedAt      time.Time      `json:"updated_at"`
+	DeletedAt      gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Problems []Problem `json:"problems,omitempty" gorm:"foreignKey:ProblemSetID"`
+	Rooms    []Room    `json:"rooms,omitempty" gorm:"many2many:problem_set_rooms;"`
+}
+
+// Problem is one reusable, versioned multi-modal challenge authored into a
+// ProblemSet. Importing it into a GameSession copies its fields into a new
+// Question; the Question keeps no link back to the Problem.
+type Problem struct {
+	ID           uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProblemSetID uuid.UUID      `json:"problem_set_id" gorm:"not null"`
+	ProblemSet   ProblemSet     `json:"problem_set" gorm:"foreignKey:ProblemSetID"`
+	Position     int            `json:"position" gorm:"not null"`
+	GameType     GameType       `json:"game_type" gorm:"not null"`
+	Title        string         `json:"title" gorm:"not null"`
+	Description  string         `json:"description"`
+	TargetData   *SensorData    `json:"target_data,omitempty" gorm:"type:jsonb"`
+	Difficulty   int            `json:"difficulty" gorm:"default:1"` // 1 (easiest) - 5 (hardest)
+	Tags         []string       `json:"tags,omitempty" gorm:"type:jsonb"`
+	Version      int            `json:"version" gorm:"default:1"`
+	CreatedAt    time.Time      `json:"created_at"`
+	UpdatedAt    time.Time      `json:"updated_at"`
+	DeletedAt    gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Attachments []ProblemAttachment `json:"attachments,omitempty" gorm:"foreignKey:ProblemID"`
+}
+
+// ProblemRevision snapshots a Problem's authoring fields each time it's
+// edited, so past versions are kept after the Problem changes. Imports use
+// the Problem's current fields, never a ProblemRevision.
+type ProblemRevision struct {
+	ID          uuid.UUID   `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProblemID   uuid.UUID   `json:"problem_id" gorm:"not null;index"`
+	Problem     Problem     `json:"problem" gorm:"foreignKey:ProblemID"`
+	Version     int         `json:"version" gorm:"not null"`
+	Title       string      `json:"title"`
+	Description string      `json:"description"`
+	TargetData  *SensorData `json:"target_data,omitempty" gorm:"type:jsonb"`
+	CreatedAt   time.Time   `json:"created_at"`
+}
+
+// AttachmentKind identifies what a ProblemAttachment's reference media is
+// used for.
+type AttachmentKind string
+
+const (
+	AttachmentKindAudio          AttachmentKind = "audio"           // VoiceData reference clip
+	AttachmentKindImage          AttachmentKind = "image"           // GestureData sample image
+	AttachmentKindGyroscopeTrace AttachmentKind = "gyroscope_trace" // recorded GyroscopeData trace
+)
+
+// ProblemAttachment is one piece of reference media attached to a Problem,
+// stored via a pluggable storage.Storage backend (local disk or S3) and
+// referenced here by key rather than embedding the bytes.
+type ProblemAttachment struct {
+	ID         uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ProblemID  uuid.UUID      `json:"problem_id" gorm:"not null"`
+	Problem    Problem        `json:"problem" gorm:"foreignKey:ProblemID"`
+	Kind       AttachmentKind `json:"kind" gorm:"not null"`
+	StorageKey string         `json:"storage_key" gorm:"not null"`
+	URL        string         `json:"url"`
+	CreatedAt  time.Time      `json:"created_at"`
+}
+
+// Category is a generic hierarchical tag attachable to Rooms and
+// ProblemSets, letting hosts browse by theme (Education > Physics >
+// Rotation) or filter the question bank.
+type Category struct {
+	ID        uuid.UUID      `json:"id" gorm:"type:uuid;primary_key;default:gen_random_uuid()"`
+	ParentID  *uuid.UUID     `json:"parent_id,omitempty"`
+	Parent    *Category      `json:"-" gorm:"foreignKey:ParentID"`
+	Name      string         `json:"name" gorm:"size:100;not null"`
+	Status    CategoryStatus `json:"status" gorm:"default:'active'"`
+	Sorter    int            `json:"sorter" gorm:"default:0"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+
+	// Relationships
+	Rooms       []Room       `json:"rooms,omitempty" gorm:"many2many:category_rooms;"`
+	ProblemSets []ProblemSet `json:"problem_sets,omitempty" gorm:"many2many:category_problem_sets;"`
+}
+
+// CategoryStatus controls whether a Category appears in nested tree queries.
+type CategoryStatus string
+
+const (
+	CategoryStatusActive   CategoryStatus = "active"
+	CategoryStatusInactive CategoryStatus = "inactive"
+)
\ No newline at end of file
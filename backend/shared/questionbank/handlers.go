@@ -0,0 +1,106 @@
+package questionbank
+
+import (
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"multimodal-platform/shared/models"
+	"multimodal-platform/shared/utils"
+)
+
+type addProblemRequest struct {
+	GameType    models.GameType    `json:"game_type" binding:"required"`
+	Title       string             `json:"title" binding:"required"`
+	Description string             `json:"description"`
+	TargetData  *models.SensorData `json:"target_data,omitempty"`
+	Difficulty  int                `json:"difficulty"`
+	Tags        []string           `json:"tags,omitempty"`
+}
+
+// AddProblemHandler handles POST /problem-sets/:id/problems.
+func (s *Service) AddProblemHandler(c *gin.Context) {
+	problemSetID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid problem set id")
+		return
+	}
+
+	var req addProblemRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid problem request", err.Error())
+		return
+	}
+
+	problem, err := s.AddProblem(problemSetID, models.Problem{
+		GameType:    req.GameType,
+		Title:       req.Title,
+		Description: req.Description,
+		TargetData:  req.TargetData,
+		Difficulty:  req.Difficulty,
+		Tags:        req.Tags,
+	})
+	if err != nil {
+		utils.InternalError(c, "Failed to add problem", err.Error())
+		return
+	}
+
+	utils.Created(c, problem)
+}
+
+// AddAttachmentHandler handles POST /problems/:id/attachments, a
+// multipart upload carrying the reference media file under the "file"
+// field and its AttachmentKind under "kind".
+func (s *Service) AddAttachmentHandler(c *gin.Context) {
+	problemID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid problem id")
+		return
+	}
+
+	kind := models.AttachmentKind(c.PostForm("kind"))
+	if kind == "" {
+		utils.BadRequest(c, "Missing attachment kind")
+		return
+	}
+
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		utils.BadRequest(c, "Missing attachment file", err.Error())
+		return
+	}
+	file, err := fileHeader.Open()
+	if err != nil {
+		utils.InternalError(c, "Failed to read attachment", err.Error())
+		return
+	}
+	defer file.Close()
+
+	attachment, err := s.AddAttachment(c.Request.Context(), problemID, kind, fileHeader.Filename, file)
+	if err != nil {
+		utils.InternalError(c, "Failed to store attachment", err.Error())
+		return
+	}
+
+	utils.Created(c, attachment)
+}
+
+type importRequest struct {
+	SessionID    uuid.UUID `json:"session_id" binding:"required"`
+	ProblemSetID uuid.UUID `json:"problem_set_id" binding:"required"`
+}
+
+// ImportHandler handles POST /game-sessions/import-problem-set.
+func (s *Service) ImportHandler(c *gin.Context) {
+	var req importRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid import request", err.Error())
+		return
+	}
+
+	questions, err := s.ImportIntoSession(req.SessionID, req.ProblemSetID)
+	if err != nil {
+		utils.InternalError(c, "Failed to import problem set", err.Error())
+		return
+	}
+
+	utils.Created(c, questions)
+}
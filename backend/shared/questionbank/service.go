@@ -0,0 +1,188 @@
+// Package questionbank lets hosts author reusable multi-modal Problems
+// offline and import them into a GameSession as copied Questions, instead
+// of creating ad-hoc Questions per session. Like shared/tournament and
+// shared/rating, it has no HTTP routes mounted in this repo yet - no
+// game-service exists to own them - but Service is ready to be wired into
+// one once it does.
+package questionbank
+
+import (
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"multimodal-platform/shared/models"
+	"multimodal-platform/shared/storage"
+)
+
+// Service manages QuestionBanks, ProblemSets, and Problems, backed by db,
+// and Problems' reference media attachments, stored via store.
+type Service struct {
+	db    *gorm.DB
+	store storage.Storage
+}
+
+// NewService returns a Service backed by db, storing attachment blobs
+// through store.
+func NewService(db *gorm.DB, store storage.Storage) *Service {
+	return &Service{db: db, store: store}
+}
+
+// AddProblem appends problem to the end of problemSetID as version 1 and
+// snapshots that version as a ProblemRevision.
+func (s *Service) AddProblem(problemSetID uuid.UUID, problem models.Problem) (*models.Problem, error) {
+	var count int64
+	if err := s.db.Model(&models.Problem{}).Where("problem_set_id = ?", problemSetID).Count(&count).Error; err != nil {
+		return nil, err
+	}
+
+	problem.ProblemSetID = problemSetID
+	problem.Position = int(count) + 1
+	problem.Version = 1
+	if err := s.db.Create(&problem).Error; err != nil {
+		return nil, err
+	}
+	if err := s.snapshotRevision(problem); err != nil {
+		return nil, err
+	}
+	return &problem, nil
+}
+
+// ReviseProblem updates a Problem's authoring fields, bumping its version
+// and snapshotting the new state to ProblemRevision as edit history.
+// ImportIntoSession always imports a Problem's current fields, never a
+// ProblemRevision.
+func (s *Service) ReviseProblem(problemID uuid.UUID, title, description string, targetData *models.SensorData) (*models.Problem, error) {
+	var problem models.Problem
+	if err := s.db.First(&problem, "id = ?", problemID).Error; err != nil {
+		return nil, err
+	}
+
+	problem.Title = title
+	problem.Description = description
+	problem.TargetData = targetData
+	problem.Version++
+
+	if err := s.db.Save(&problem).Error; err != nil {
+		return nil, err
+	}
+	if err := s.snapshotRevision(problem); err != nil {
+		return nil, err
+	}
+	return &problem, nil
+}
+
+// AddAttachment stores r under a key namespaced to problemID via the
+// Service's Storage backend, then records a ProblemAttachment row
+// pointing at the returned key and URL.
+func (s *Service) AddAttachment(ctx context.Context, problemID uuid.UUID, kind models.AttachmentKind, filename string, r io.Reader) (*models.ProblemAttachment, error) {
+	key := fmt.Sprintf("problems/%s/%s-%s", problemID, uuid.NewString(), filename)
+	url, err := s.store.Put(ctx, key, r)
+	if err != nil {
+		return nil, err
+	}
+
+	attachment := models.ProblemAttachment{
+		ProblemID:  problemID,
+		Kind:       kind,
+		StorageKey: key,
+		URL:        url,
+	}
+	if err := s.db.Create(&attachment).Error; err != nil {
+		return nil, err
+	}
+	return &attachment, nil
+}
+
+func (s *Service) snapshotRevision(problem models.Problem) error {
+	revision := models.ProblemRevision{
+		ProblemID:   problem.ID,
+		Version:     problem.Version,
+		Title:       problem.Title,
+		Description: problem.Description,
+		TargetData:  problem.TargetData,
+	}
+	return s.db.Create(&revision).Error
+}
+
+// ImportIntoSession copies every Problem in problemSetID into sessionID as
+// ordered Questions, copying each Problem's current GameType, Title,
+// Description and TargetData so the session doesn't depend on the
+// ProblemSet afterward. Attachments are not copied, and the Questions keep
+// no link back to their Problems.
+func (s *Service) ImportIntoSession(sessionID, problemSetID uuid.UUID) ([]models.Question, error) {
+	var problems []models.Problem
+	err := s.db.Where("problem_set_id = ?", problemSetID).Order("position").Find(&problems).Error
+	if err != nil {
+		return nil, err
+	}
+
+	questions := make([]models.Question, 0, len(problems))
+	for i, problem := range problems {
+		questions = append(questions, models.Question{
+			SessionID:   sessionID,
+			Type:        problem.GameType,
+			Title:       problem.Title,
+			Description: problem.Description,
+			TargetData:  problem.TargetData,
+			RoundNumber: i + 1,
+		})
+	}
+
+	if len(questions) == 0 {
+		return questions, nil
+	}
+	if err := s.db.Create(&questions).Error; err != nil {
+		return nil, err
+	}
+	return questions, nil
+}
+
+// BackfillAdHocQuestions migrates every pre-existing ad-hoc Question for
+// sessionID into a new ProblemSet under bankID, one Problem per Question in
+// round order, so the host's old sessions can be curated and reused going
+// forward. It is not idempotent: every call creates a new ProblemSet, so
+// calling it twice for the same session creates two.
+func (s *Service) BackfillAdHocQuestions(bankID, sessionID uuid.UUID) (*models.ProblemSet, error) {
+	var session models.GameSession
+	if err := s.db.First(&session, "id = ?", sessionID).Error; err != nil {
+		return nil, err
+	}
+
+	var questions []models.Question
+	err := s.db.Where("session_id = ?", sessionID).Order("round_number").Find(&questions).Error
+	if err != nil {
+		return nil, err
+	}
+
+	problemSet := models.ProblemSet{
+		QuestionBankID: bankID,
+		Name:           fmt.Sprintf("Backfilled from session %s", session.ID),
+		Visibility:     models.VisibilityPrivate,
+	}
+	if err := s.db.Create(&problemSet).Error; err != nil {
+		return nil, err
+	}
+
+	for i, question := range questions {
+		problem := models.Problem{
+			ProblemSetID: problemSet.ID,
+			Position:     i + 1,
+			GameType:     question.Type,
+			Title:        question.Title,
+			Description:  question.Description,
+			TargetData:   question.TargetData,
+			Version:      1,
+		}
+		if err := s.db.Create(&problem).Error; err != nil {
+			return nil, err
+		}
+		if err := s.snapshotRevision(problem); err != nil {
+			return nil, err
+		}
+	}
+
+	return &problemSet, nil
+}
@@ -0,0 +1,51 @@
+// Package ratelimit implements a Redis-backed fixed-window rate limiter
+// shared across every instance of a service, so a limit on a scope/key
+// holds cluster-wide rather than per-process.
+package ratelimit
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+)
+
+// Limiter counts requests per (scope, key) in fixed windows under
+// rl:<scope>:<key>:<window>, using INCR+EXPIRE so idle counters clean
+// themselves up.
+type Limiter struct {
+	client *redis.Client
+}
+
+// New returns a Limiter backed by client.
+func New(client *redis.Client) *Limiter {
+	return &Limiter{client: client}
+}
+
+// Allow reports whether another request for (scope, key) is permitted
+// under limit requests per window, and if not, how long until the window
+// resets. A Redis error fails open (allowed=true) so an outage doesn't take
+// the service down with it.
+func (l *Limiter) Allow(ctx context.Context, scope, key string, limit int, window time.Duration) (allowed bool, retryAfter time.Duration, err error) {
+	bucket := time.Now().Unix() / int64(window.Seconds())
+	redisKey := fmt.Sprintf("rl:%s:%s:%d", scope, key, bucket)
+
+	count, err := l.client.Incr(ctx, redisKey).Result()
+	if err != nil {
+		return true, 0, err
+	}
+	if count == 1 {
+		l.client.Expire(ctx, redisKey, window)
+	}
+
+	if count <= int64(limit) {
+		return true, 0, nil
+	}
+
+	ttl, err := l.client.TTL(ctx, redisKey).Result()
+	if err != nil || ttl < 0 {
+		ttl = window
+	}
+	return false, ttl, nil
+}
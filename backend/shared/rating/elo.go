@@ -0,0 +1,35 @@
+// Package rating maintains per-(user, GameType) Elo ratings on top of the
+// PlayerRating and RatingChange models in multimodal-platform/shared/models.
+// Like shared/tournament, it has no HTTP routes wired to it yet since no
+// game-service exists in this repo; Service (service.go, with its gin
+// handler in handlers.go) is ready to be mounted once one does.
+package rating
+
+import "math"
+
+// DefaultInitialRating is the rating a player starts at before playing any
+// rated GameSession.
+const DefaultInitialRating = 1200.0
+
+// DefaultK is the Elo K-factor used when a GameType has no override in
+// Service.kFactors.
+const DefaultK = 32.0
+
+// ExpectedScore returns the probability that a player rated ra beats a
+// player rated rb, per the standard Elo logistic curve.
+func ExpectedScore(ra, rb float64) float64 {
+	return 1 / (1 + math.Pow(10, (rb-ra)/400))
+}
+
+// ActualScore derives the Elo outcome (1 win, 0.5 draw, 0 loss) for a player
+// from their PlayerScore.Score relative to a single opponent's.
+func ActualScore(scoreA, scoreB int) float64 {
+	switch {
+	case scoreA > scoreB:
+		return 1
+	case scoreA < scoreB:
+		return 0
+	default:
+		return 0.5
+	}
+}
@@ -0,0 +1,34 @@
+package rating
+
+import (
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"multimodal-platform/shared/models"
+	"multimodal-platform/shared/utils"
+)
+
+// LeaderboardHandler handles GET requests for a GameType's leaderboard, e.g.
+// /games/:type/leaderboard?season=2&limit=50.
+func (s *Service) LeaderboardHandler(c *gin.Context) {
+	gameType := models.GameType(c.Param("type"))
+
+	season, err := strconv.Atoi(c.DefaultQuery("season", "1"))
+	if err != nil || season < 1 {
+		utils.BadRequest(c, "Invalid season")
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "50"))
+	if err != nil || limit < 1 {
+		utils.BadRequest(c, "Invalid limit")
+		return
+	}
+
+	ratings, err := s.Leaderboard(gameType, season, limit)
+	if err != nil {
+		utils.InternalError(c, "Failed to load leaderboard", err.Error())
+		return
+	}
+
+	utils.Success(c, ratings)
+}
@@ -0,0 +1,173 @@
+package rating
+
+import (
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"multimodal-platform/shared/models"
+)
+
+// Service updates and queries PlayerRatings, backed by db. kFactors
+// overrides DefaultK per GameType; a GameType absent from the map uses
+// DefaultK.
+type Service struct {
+	db       *gorm.DB
+	kFactors map[models.GameType]float64
+}
+
+// NewService returns a Service backed by db, using kFactors as per-GameType
+// K-factor overrides (nil is fine; every GameType then uses DefaultK).
+func NewService(db *gorm.DB, kFactors map[models.GameType]float64) *Service {
+	return &Service{db: db, kFactors: kFactors}
+}
+
+func (s *Service) kFactor(gameType models.GameType) float64 {
+	if k, ok := s.kFactors[gameType]; ok {
+		return k
+	}
+	return DefaultK
+}
+
+// UpdateRatingsForSession loads every PlayerScore for a finished
+// GameSession and updates each player's PlayerRating via pairwise Elo
+// comparisons against every other player in the session, weighted down by
+// the number of opponents so a single session moves a rating by roughly one
+// game's worth of change regardless of player count. One RatingChange row
+// is written per player summarizing the session's net delta.
+func (s *Service) UpdateRatingsForSession(sessionID uuid.UUID) error {
+	var session models.GameSession
+	if err := s.db.First(&session, "id = ?", sessionID).Error; err != nil {
+		return err
+	}
+
+	var scores []models.PlayerScore
+	if err := s.db.Where("session_id = ?", sessionID).Find(&scores).Error; err != nil {
+		return err
+	}
+	if len(scores) < 2 {
+		return nil
+	}
+
+	season, err := s.currentSeason(session.GameType)
+	if err != nil {
+		return err
+	}
+
+	ratings := make(map[uuid.UUID]float64, len(scores))
+	for _, sc := range scores {
+		r, err := s.ratingFor(sc.UserID, session.GameType, season)
+		if err != nil {
+			return err
+		}
+		ratings[sc.UserID] = r
+	}
+
+	k := s.kFactor(session.GameType)
+	deltas := make(map[uuid.UUID]float64, len(scores))
+	for i := range scores {
+		for j := range scores {
+			if i == j {
+				continue
+			}
+			a, b := scores[i], scores[j]
+			expected := ExpectedScore(ratings[a.UserID], ratings[b.UserID])
+			actual := ActualScore(a.Score, b.Score)
+			deltas[a.UserID] += k * (actual - expected)
+		}
+	}
+
+	for _, sc := range scores {
+		old := ratings[sc.UserID]
+		delta := deltas[sc.UserID] / float64(len(scores)-1)
+		newRating := old + delta
+
+		err := s.db.Model(&models.PlayerRating{}).
+			Where("user_id = ? AND game_type = ? AND season = ?", sc.UserID, session.GameType, season).
+			Update("rating", newRating).Error
+		if err != nil {
+			return err
+		}
+
+		change := models.RatingChange{
+			UserID:    sc.UserID,
+			GameType:  session.GameType,
+			SessionID: sessionID,
+			OldRating: old,
+			NewRating: newRating,
+			Delta:     delta,
+		}
+		if err := s.db.Create(&change).Error; err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ratingFor returns userID's rating for gameType in season, creating a
+// PlayerRating row at DefaultInitialRating if this is their first time
+// playing that season.
+func (s *Service) ratingFor(userID uuid.UUID, gameType models.GameType, season int) (float64, error) {
+	rating := models.PlayerRating{
+		UserID:   userID,
+		GameType: gameType,
+		Season:   season,
+		Rating:   DefaultInitialRating,
+	}
+	err := s.db.Where("user_id = ? AND game_type = ? AND season = ?", userID, gameType, season).
+		FirstOrCreate(&rating).Error
+	return rating.Rating, err
+}
+
+// currentSeason returns the highest season number recorded for gameType,
+// or 1 if nothing has played it yet.
+func (s *Service) currentSeason(gameType models.GameType) (int, error) {
+	var season int
+	err := s.db.Model(&models.PlayerRating{}).
+		Where("game_type = ?", gameType).
+		Select("COALESCE(MAX(season), 1)").
+		Scan(&season).Error
+	return season, err
+}
+
+// Leaderboard returns the top limit PlayerRatings for gameType and season,
+// highest rating first.
+func (s *Service) Leaderboard(gameType models.GameType, season, limit int) ([]models.PlayerRating, error) {
+	var ratings []models.PlayerRating
+	err := s.db.Where("game_type = ? AND season = ?", gameType, season).
+		Order("rating DESC").
+		Limit(limit).
+		Find(&ratings).Error
+	return ratings, err
+}
+
+// ResetSeason starts every player who has a gameType rating this season
+// back at DefaultInitialRating under the next season number. Unlike an
+// in-place update, this inserts new PlayerRating rows rather than
+// overwriting the current ones: PlayerRating is keyed by (user, game
+// type, season), so the just-finished season's rows -- and its
+// Leaderboard -- stay exactly as they were, and RatingChange rows remain
+// untouched so past seasons stay fully visible either way.
+func (s *Service) ResetSeason(gameType models.GameType) error {
+	season, err := s.currentSeason(gameType)
+	if err != nil {
+		return err
+	}
+
+	var current []models.PlayerRating
+	if err := s.db.Where("game_type = ? AND season = ?", gameType, season).Find(&current).Error; err != nil {
+		return err
+	}
+
+	next := make([]models.PlayerRating, 0, len(current))
+	for _, r := range current {
+		next = append(next, models.PlayerRating{
+			UserID:   r.UserID,
+			GameType: gameType,
+			Season:   season + 1,
+			Rating:   DefaultInitialRating,
+		})
+	}
+	if len(next) == 0 {
+		return nil
+	}
+	return s.db.Create(&next).Error
+}
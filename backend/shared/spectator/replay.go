@@ -0,0 +1,120 @@
+// Package spectator reconstructs a finished GameSession's PlayerResponse
+// stream, bullet-chat overlays, and chat history into a seekable replay.
+// Like shared/tournament and shared/rating, it has no HTTP routes mounted
+// in this repo yet - no game-service exists to own them - but Service is
+// ready to be wired into one. Live (in-progress) spectating is handled
+// separately by websocket-service's SpectatorBroker, which persists each
+// BulletMessage as it's broadcast (best-effort, skipped entirely on
+// instances with no DATABASE_URL) precisely so this package has rows to
+// reconstruct; this package itself only serves completed sessions back.
+package spectator
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"multimodal-platform/shared/models"
+)
+
+// Service reconstructs replays from db.
+type Service struct {
+	db *gorm.DB
+}
+
+// NewService returns a Service backed by db.
+func NewService(db *gorm.DB) *Service {
+	return &Service{db: db}
+}
+
+// Frame is one moment of a replay: the PlayerResponse recorded at that
+// point plus every BulletMessage that appeared around the same time,
+// offset from the session's start so clients can render them client-side
+// without re-deriving wall-clock math.
+type Frame struct {
+	OffsetMillis int64                   `json:"offset_millis"`
+	Response     models.PlayerResponse   `json:"response"`
+	Bullets      []models.BulletMessage  `json:"bullets"`
+}
+
+// Replay is a finished GameSession's full reconstructed timeline.
+type Replay struct {
+	SessionID   uuid.UUID `json:"session_id"`
+	DurationMS  int64     `json:"duration_ms"`
+	Frames      []Frame   `json:"frames"`
+}
+
+// Build reconstructs sessionID's full replay from its stored
+// PlayerResponse and BulletMessage rows, both ordered by CreatedAt.
+func (s *Service) Build(sessionID uuid.UUID) (*Replay, error) {
+	var session models.GameSession
+	if err := s.db.First(&session, "id = ?", sessionID).Error; err != nil {
+		return nil, err
+	}
+	if session.StartTime == nil {
+		return &Replay{SessionID: sessionID}, nil
+	}
+	start := *session.StartTime
+
+	var responses []models.PlayerResponse
+	err := s.db.Where("session_id = ?", sessionID).Order("created_at").Find(&responses).Error
+	if err != nil {
+		return nil, err
+	}
+
+	var bullets []models.BulletMessage
+	err = s.db.Where("session_id = ?", sessionID).Order("created_at").Find(&bullets).Error
+	if err != nil {
+		return nil, err
+	}
+
+	frames := make([]Frame, 0, len(responses))
+	for _, response := range responses {
+		frames = append(frames, Frame{
+			OffsetMillis: response.CreatedAt.Sub(start).Milliseconds(),
+			Response:     response,
+			Bullets:      bulletsNear(bullets, start, response.CreatedAt),
+		})
+	}
+
+	duration := int64(0)
+	if session.EndTime != nil {
+		duration = session.EndTime.Sub(start).Milliseconds()
+	} else if len(frames) > 0 {
+		duration = frames[len(frames)-1].OffsetMillis
+	}
+
+	return &Replay{SessionID: sessionID, DurationMS: duration, Frames: frames}, nil
+}
+
+// Seek returns every frame of a replay at or after offsetMillis, so a
+// client scrubbing the playback can jump straight to that point instead of
+// replaying everything from the start.
+func (r *Replay) Seek(offsetMillis int64) []Frame {
+	for i, frame := range r.Frames {
+		if frame.OffsetMillis >= offsetMillis {
+			return r.Frames[i:]
+		}
+	}
+	return nil
+}
+
+// bulletFrameWindow is how close (in wall-clock time) a BulletMessage must
+// be to a PlayerResponse to be attached to that response's Frame.
+const bulletFrameWindow = 500 * time.Millisecond
+
+// bulletsNear returns the BulletMessages that appeared within
+// bulletFrameWindow of responseTime. bullets must be sorted by CreatedAt.
+func bulletsNear(bullets []models.BulletMessage, start, responseTime time.Time) []models.BulletMessage {
+	var near []models.BulletMessage
+	for _, b := range bullets {
+		delta := b.CreatedAt.Sub(responseTime)
+		if delta < 0 {
+			delta = -delta
+		}
+		if delta <= bulletFrameWindow {
+			near = append(near, b)
+		}
+	}
+	return near
+}
@@ -0,0 +1,60 @@
+package storage
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+// LocalStorage stores attachments as files under baseDir, serving them back
+// from baseURL (e.g. a static file server or reverse-proxied path) for
+// local development and single-node deployments.
+type LocalStorage struct {
+	baseDir string
+	baseURL string
+}
+
+// NewLocalStorage returns a LocalStorage rooted at baseDir, whose files are
+// reachable at baseURL/<key>.
+func NewLocalStorage(baseDir, baseURL string) *LocalStorage {
+	return &LocalStorage{baseDir: baseDir, baseURL: baseURL}
+}
+
+func (s *LocalStorage) path(key string) string {
+	return filepath.Join(s.baseDir, filepath.Clean("/"+key))
+}
+
+// Put writes r to baseDir/key, creating any intermediate directories.
+func (s *LocalStorage) Put(ctx context.Context, key string, r io.Reader) (string, error) {
+	path := s.path(key)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return "", err
+	}
+
+	f, err := os.Create(path)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	if _, err := io.Copy(f, r); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s", s.baseURL, key), nil
+}
+
+// Get opens the file stored at key.
+func (s *LocalStorage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	return os.Open(s.path(key))
+}
+
+// Delete removes the file stored at key.
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	err := os.Remove(s.path(key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+	return err
+}
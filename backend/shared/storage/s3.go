@@ -0,0 +1,63 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+)
+
+// S3Storage stores attachments as objects in a single S3 bucket.
+type S3Storage struct {
+	client  *s3.Client
+	bucket  string
+	baseURL string // public/CDN URL the bucket's objects are served from
+}
+
+// NewS3Storage returns an S3Storage backed by client, storing objects in
+// bucket and serving them back from baseURL/<key>.
+func NewS3Storage(client *s3.Client, bucket, baseURL string) *S3Storage {
+	return &S3Storage{client: client, bucket: bucket, baseURL: baseURL}
+}
+
+// Put uploads r's contents to bucket/key.
+func (s *S3Storage) Put(ctx context.Context, key string, r io.Reader) (string, error) {
+	body, err := io.ReadAll(r)
+	if err != nil {
+		return "", err
+	}
+
+	_, err = s.client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+		Body:   bytes.NewReader(body),
+	})
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/%s", s.baseURL, key), nil
+}
+
+// Get downloads bucket/key.
+func (s *S3Storage) Get(ctx context.Context, key string) (io.ReadCloser, error) {
+	out, err := s.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out.Body, nil
+}
+
+// Delete removes bucket/key.
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	_, err := s.client.DeleteObject(ctx, &s3.DeleteObjectInput{
+		Bucket: aws.String(s.bucket),
+		Key:    aws.String(key),
+	})
+	return err
+}
@@ -0,0 +1,18 @@
+// Package storage provides a pluggable backend for Problem reference media
+// (audio clips, sample images, recorded sensor traces), so ProblemAttachment
+// rows can reference a key without caring whether it lives on local disk or
+// in S3.
+package storage
+
+import (
+	"context"
+	"io"
+)
+
+// Storage stores and retrieves attachment blobs by key. Put returns the URL
+// clients should fetch the blob from.
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader) (url string, err error)
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
@@ -0,0 +1,167 @@
+// Package tournament generates and advances single-elimination and
+// round-robin brackets on top of the Tournament models in
+// multimodal-platform/shared/models. It holds pure bracket logic only; no
+// HTTP routing exists for it yet since no game-service lives in this repo,
+// but Service in handlers.go is ready to be mounted once one does.
+package tournament
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+	"multimodal-platform/shared/models"
+)
+
+// ErrUnresolvedMatch is returned by AdvanceWinner when neither participant's
+// score is strictly greater than the other's.
+var ErrUnresolvedMatch = errors.New("tournament: match ended in a tie and needs a tiebreaker")
+
+// ErrIncompleteMatch is returned by AdvanceWinner when a non-bye match is
+// missing one of its participants.
+var ErrIncompleteMatch = errors.New("tournament: match is missing a participant")
+
+// ErrUnsupportedFormat is reported by CreateBracket for any
+// TournamentFormat other than single elimination; there is no round-robin
+// scheduling, and bracketing round-robin as single-elimination would
+// produce the wrong schedule.
+var ErrUnsupportedFormat = errors.New("tournament: round-robin format is not supported yet")
+
+// ErrNotEnoughParticipants is returned by GenerateFirstRound when there are
+// fewer than two participants to seed a bracket from.
+var ErrNotEnoughParticipants = errors.New("tournament: at least two participants are required to generate a bracket")
+
+// SeedParticipants assigns seed numbers 1..N to participants in the order
+// given, so callers control seeding (by rating, by join order, etc.) simply
+// by sorting the slice beforehand.
+func SeedParticipants(userIDs []uuid.UUID, tournamentID uuid.UUID) []models.TournamentParticipant {
+	participants := make([]models.TournamentParticipant, len(userIDs))
+	for i, userID := range userIDs {
+		participants[i] = models.TournamentParticipant{
+			TournamentID: tournamentID,
+			UserID:       userID,
+			Seed:         i + 1,
+		}
+	}
+	return participants
+}
+
+// nextPowerOfTwo returns the smallest power of two >= n, the bracket size
+// that leaves room for byes when n isn't already one.
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p *= 2
+	}
+	return p
+}
+
+// seedOrder returns the standard tournament seeding order for a bracket of
+// size n (a power of two): result[2i] and result[2i+1] are the seeds paired
+// in round one, with seed 1 meeting the weakest possible opponent and the
+// strongest seeds kept apart for as long as possible.
+func seedOrder(n int) []int {
+	if n <= 1 {
+		return []int{1}
+	}
+	prev := seedOrder(n / 2)
+	order := make([]int, 0, n)
+	for _, s := range prev {
+		order = append(order, s, n+1-s)
+	}
+	return order
+}
+
+// GenerateFirstRound pairs seeded participants into round-one matches,
+// padding the bracket out to the next power of two with byes so every
+// unseeded slot auto-advances its opponent. It returns ErrNotEnoughParticipants
+// for fewer than two participants, since there's no bracket to build (a
+// bracket of size 1 has no second slot for the pairing loop to read).
+func GenerateFirstRound(participants []models.TournamentParticipant) ([]models.TournamentMatch, error) {
+	if len(participants) < 2 {
+		return nil, ErrNotEnoughParticipants
+	}
+
+	size := nextPowerOfTwo(len(participants))
+	order := seedOrder(size)
+
+	bySeed := make(map[int]*models.TournamentParticipant, len(participants))
+	for i := range participants {
+		bySeed[participants[i].Seed] = &participants[i]
+	}
+
+	matches := make([]models.TournamentMatch, 0, size/2)
+	for i := 0; i < size; i += 2 {
+		a := bySeed[order[i]]
+		b := bySeed[order[i+1]]
+
+		match := models.TournamentMatch{}
+		switch {
+		case a != nil && b != nil:
+			match.ParticipantAID = &a.ID
+			match.ParticipantBID = &b.ID
+		case a != nil:
+			match.ParticipantAID = &a.ID
+			match.IsBye = true
+			match.WinnerID = &a.ID
+		case b != nil:
+			match.ParticipantBID = &b.ID
+			match.IsBye = true
+			match.WinnerID = &b.ID
+		}
+		matches = append(matches, match)
+	}
+	return matches, nil
+}
+
+// AdvanceWinner sets match.WinnerID from each participant's aggregated
+// score, keyed by TournamentParticipant ID, and flags the loser as
+// Eliminated on participantA/participantB (whichever one lost). Bye
+// matches are already resolved and are left untouched.
+func AdvanceWinner(match *models.TournamentMatch, participantA, participantB *models.TournamentParticipant, scoreByParticipant map[uuid.UUID]int) error {
+	if match.IsBye {
+		return nil
+	}
+	if match.ParticipantAID == nil || match.ParticipantBID == nil {
+		return ErrIncompleteMatch
+	}
+
+	scoreA := scoreByParticipant[*match.ParticipantAID]
+	scoreB := scoreByParticipant[*match.ParticipantBID]
+
+	switch {
+	case scoreA > scoreB:
+		match.WinnerID = match.ParticipantAID
+		participantB.Eliminated = true
+	case scoreB > scoreA:
+		match.WinnerID = match.ParticipantBID
+		participantA.Eliminated = true
+	default:
+		return ErrUnresolvedMatch
+	}
+	return nil
+}
+
+// NextRound pairs off the winners of a completed round in match order,
+// producing the matches for the round that follows. It assumes every match
+// in completed has already had its WinnerID set via AdvanceWinner.
+func NextRound(completed []models.TournamentMatch) []models.TournamentMatch {
+	matches := make([]models.TournamentMatch, 0, len(completed)/2)
+	for i := 0; i < len(completed)-1; i += 2 {
+		matches = append(matches, models.TournamentMatch{
+			ParticipantAID: completed[i].WinnerID,
+			ParticipantBID: completed[i+1].WinnerID,
+		})
+	}
+	return matches
+}
+
+// IsRoundComplete reports whether every match in a round has a winner
+// (bye matches are resolved at creation time).
+func IsRoundComplete(matches []models.TournamentMatch) bool {
+	for _, m := range matches {
+		if m.WinnerID == nil {
+			return false
+		}
+	}
+	return true
+}
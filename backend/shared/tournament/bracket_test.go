@@ -0,0 +1,85 @@
+package tournament
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/uuid"
+	"multimodal-platform/shared/models"
+)
+
+func TestGenerateFirstRoundRejectsTooFewParticipants(t *testing.T) {
+	for _, n := range []int{0, 1} {
+		participants := SeedParticipants(make([]uuid.UUID, n), uuid.New())
+		if _, err := GenerateFirstRound(participants); !errors.Is(err, ErrNotEnoughParticipants) {
+			t.Errorf("GenerateFirstRound with %d participants: got err %v, want ErrNotEnoughParticipants", n, err)
+		}
+	}
+}
+
+func TestGenerateFirstRoundPadsWithByes(t *testing.T) {
+	tournamentID := uuid.New()
+	participants := SeedParticipants([]uuid.UUID{uuid.New(), uuid.New(), uuid.New()}, tournamentID)
+
+	matches, err := GenerateFirstRound(participants)
+	if err != nil {
+		t.Fatalf("GenerateFirstRound: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Fatalf("got %d matches, want 2 (bracket padded to 4 for 3 participants)", len(matches))
+	}
+
+	byes := 0
+	for _, m := range matches {
+		if m.IsBye {
+			byes++
+			if m.WinnerID == nil {
+				t.Error("bye match has no WinnerID set")
+			}
+		}
+	}
+	if byes != 1 {
+		t.Errorf("got %d bye matches, want 1", byes)
+	}
+}
+
+func TestAdvanceWinnerFlagsLoserEliminated(t *testing.T) {
+	match := &models.TournamentMatch{
+		ParticipantAID: uuidPtr(uuid.New()),
+		ParticipantBID: uuidPtr(uuid.New()),
+	}
+	a := &models.TournamentParticipant{ID: *match.ParticipantAID}
+	b := &models.TournamentParticipant{ID: *match.ParticipantBID}
+	scores := map[uuid.UUID]int{a.ID: 10, b.ID: 3}
+
+	if err := AdvanceWinner(match, a, b, scores); err != nil {
+		t.Fatalf("AdvanceWinner: %v", err)
+	}
+	if match.WinnerID == nil || *match.WinnerID != a.ID {
+		t.Errorf("WinnerID = %v, want participant A", match.WinnerID)
+	}
+	if !b.Eliminated {
+		t.Error("losing participant B was not flagged Eliminated")
+	}
+	if a.Eliminated {
+		t.Error("winning participant A was incorrectly flagged Eliminated")
+	}
+}
+
+func TestAdvanceWinnerRejectsTies(t *testing.T) {
+	match := &models.TournamentMatch{
+		ParticipantAID: uuidPtr(uuid.New()),
+		ParticipantBID: uuidPtr(uuid.New()),
+	}
+	a := &models.TournamentParticipant{ID: *match.ParticipantAID}
+	b := &models.TournamentParticipant{ID: *match.ParticipantBID}
+	scores := map[uuid.UUID]int{a.ID: 5, b.ID: 5}
+
+	if err := AdvanceWinner(match, a, b, scores); !errors.Is(err, ErrUnresolvedMatch) {
+		t.Errorf("got err %v, want ErrUnresolvedMatch", err)
+	}
+}
+
+func uuidPtr(id uuid.UUID) *uuid.UUID {
+	return &id
+}
@@ -0,0 +1,339 @@
+package tournament
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"multimodal-platform/shared/models"
+	"multimodal-platform/shared/utils"
+)
+
+// Service exposes tournament bracket operations as gin handlers backed by
+// db. Nothing in this repo registers these routes yet — no game-service
+// exists to mount them on — so wire Service's methods into that service's
+// router once it does.
+type Service struct {
+	db *gorm.DB
+}
+
+// NewService returns a Service backed by db.
+func NewService(db *gorm.DB) *Service {
+	return &Service{db: db}
+}
+
+type createBracketRequest struct {
+	Name           string                  `json:"name" binding:"required"`
+	Format         models.TournamentFormat `json:"format" binding:"required"`
+	HostID         uuid.UUID               `json:"host_id" binding:"required"`
+	ParticipantIDs []uuid.UUID             `json:"participant_ids" binding:"required,min=2"`
+}
+
+// CreateBracket creates a Tournament in the seeding state and seeds its
+// participants in the order given. The bracket itself isn't generated yet,
+// so the host (or an admin, via OverrideSeeds) has a window to adjust seeds
+// before StartBracket locks them in and generates round one.
+func (s *Service) CreateBracket(c *gin.Context) {
+	var req createBracketRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid tournament request", err.Error())
+		return
+	}
+	if req.Format != models.TournamentFormatSingleElimination {
+		utils.BadRequest(c, "Unsupported tournament format", ErrUnsupportedFormat.Error())
+		return
+	}
+
+	tourn := models.Tournament{
+		Name:   req.Name,
+		Format: req.Format,
+		Status: models.TournamentStatusSeeding,
+		HostID: req.HostID,
+	}
+	if err := s.db.Create(&tourn).Error; err != nil {
+		utils.InternalError(c, "Failed to create tournament", err.Error())
+		return
+	}
+
+	participants := SeedParticipants(req.ParticipantIDs, tourn.ID)
+	if err := s.db.Create(&participants).Error; err != nil {
+		utils.InternalError(c, "Failed to seed participants", err.Error())
+		return
+	}
+
+	tourn.Participants = participants
+	utils.Created(c, tourn)
+}
+
+type overrideSeedsRequest struct {
+	Seeds map[uuid.UUID]int `json:"seeds" binding:"required"`
+}
+
+// OverrideSeeds lets an admin reassign participant seeds while a tournament
+// is still in the seeding phase, e.g. to seed by rating instead of join
+// order. It's rejected once StartBracket has generated round one, since
+// matches are already paired off by that point.
+func (s *Service) OverrideSeeds(c *gin.Context) {
+	tournamentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid tournament id")
+		return
+	}
+
+	var req overrideSeedsRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		utils.BadRequest(c, "Invalid seed override request", err.Error())
+		return
+	}
+
+	var tourn models.Tournament
+	if err := s.db.First(&tourn, "id = ?", tournamentID).Error; err != nil {
+		utils.NotFound(c, "Tournament not found")
+		return
+	}
+	if tourn.Status != models.TournamentStatusSeeding {
+		utils.ValidationError(c, "Seeds can only be overridden before the bracket is generated", "")
+		return
+	}
+
+	for participantID, seed := range req.Seeds {
+		err := s.db.Model(&models.TournamentParticipant{}).
+			Where("id = ? AND tournament_id = ?", participantID, tournamentID).
+			Update("seed", seed).Error
+		if err != nil {
+			utils.InternalError(c, "Failed to override seed", err.Error())
+			return
+		}
+	}
+
+	var participants []models.TournamentParticipant
+	if err := s.db.Where("tournament_id = ?", tournamentID).Order("seed").Find(&participants).Error; err != nil {
+		utils.InternalError(c, "Failed to load standings", err.Error())
+		return
+	}
+	utils.Success(c, participants)
+}
+
+// StartBracket locks in the current seeds and generates round one, moving
+// the tournament from seeding into its first in-progress round.
+func (s *Service) StartBracket(c *gin.Context) {
+	tournamentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid tournament id")
+		return
+	}
+
+	var tourn models.Tournament
+	if err := s.db.First(&tourn, "id = ?", tournamentID).Error; err != nil {
+		utils.NotFound(c, "Tournament not found")
+		return
+	}
+	if tourn.Status != models.TournamentStatusSeeding {
+		utils.ValidationError(c, "Tournament has already been started", "")
+		return
+	}
+
+	var participants []models.TournamentParticipant
+	if err := s.db.Where("tournament_id = ?", tournamentID).Order("seed").Find(&participants).Error; err != nil {
+		utils.InternalError(c, "Failed to load participants", err.Error())
+		return
+	}
+
+	matches, err := GenerateFirstRound(participants)
+	if err != nil {
+		utils.ValidationError(c, "Could not generate bracket", err.Error())
+		return
+	}
+
+	round := models.TournamentRound{
+		TournamentID: tournamentID,
+		RoundNumber:  1,
+		Matches:      matches,
+	}
+	if err := s.db.Create(&round).Error; err != nil {
+		utils.InternalError(c, "Failed to generate bracket", err.Error())
+		return
+	}
+
+	tourn.Status = models.TournamentStatusInProgress
+	tourn.CurrentRound = 1
+	if err := s.db.Save(&tourn).Error; err != nil {
+		utils.InternalError(c, "Failed to start tournament", err.Error())
+		return
+	}
+
+	tourn.Participants = participants
+	tourn.Rounds = []models.TournamentRound{round}
+	utils.Success(c, tourn)
+}
+
+// ReportResult records a match's outcome, derived from the PlayerScore rows
+// persisted for its GameSession (never from caller input, which would let
+// any client report an arbitrary winner), and, once every match in the
+// round has a winner, generates the next round (or marks the tournament
+// finished if this was the final).
+func (s *Service) ReportResult(c *gin.Context) {
+	matchID, err := uuid.Parse(c.Param("matchId"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid match id")
+		return
+	}
+
+	var match models.TournamentMatch
+	if err := s.db.First(&match, "id = ?", matchID).Error; err != nil {
+		utils.NotFound(c, "Match not found")
+		return
+	}
+
+	participantA, participantB, err := s.loadMatchParticipants(&match)
+	if err != nil {
+		utils.InternalError(c, "Failed to load match participants", err.Error())
+		return
+	}
+
+	scoreByParticipant, err := s.scoresForMatch(&match)
+	if err != nil {
+		utils.InternalError(c, "Failed to load match scores", err.Error())
+		return
+	}
+
+	if err := AdvanceWinner(&match, participantA, participantB, scoreByParticipant); err != nil {
+		utils.ValidationError(c, "Could not resolve match winner", err.Error())
+		return
+	}
+	if err := s.db.Save(&match).Error; err != nil {
+		utils.InternalError(c, "Failed to save match result", err.Error())
+		return
+	}
+	if participantA != nil && participantA.Eliminated {
+		if err := s.db.Model(participantA).Update("eliminated", true).Error; err != nil {
+			utils.InternalError(c, "Failed to record elimination", err.Error())
+			return
+		}
+	}
+	if participantB != nil && participantB.Eliminated {
+		if err := s.db.Model(participantB).Update("eliminated", true).Error; err != nil {
+			utils.InternalError(c, "Failed to record elimination", err.Error())
+			return
+		}
+	}
+
+	var round models.TournamentRound
+	if err := s.db.Preload("Matches").First(&round, "id = ?", match.RoundID).Error; err != nil {
+		utils.InternalError(c, "Failed to load round", err.Error())
+		return
+	}
+	if !IsRoundComplete(round.Matches) {
+		utils.Success(c, match)
+		return
+	}
+
+	if len(round.Matches) == 1 {
+		s.finishTournament(c, round.TournamentID, round.Matches[0].WinnerID)
+		return
+	}
+
+	nextRound := models.TournamentRound{
+		TournamentID: round.TournamentID,
+		RoundNumber:  round.RoundNumber + 1,
+		Matches:      NextRound(round.Matches),
+	}
+	if err := s.db.Create(&nextRound).Error; err != nil {
+		utils.InternalError(c, "Failed to generate next round", err.Error())
+		return
+	}
+	s.db.Model(&models.Tournament{}).Where("id = ?", round.TournamentID).
+		Update("current_round", nextRound.RoundNumber)
+
+	utils.Success(c, gin.H{"match": match, "next_round": nextRound})
+}
+
+// loadMatchParticipants loads match's ParticipantAID/ParticipantBID rows,
+// returning nil for either side that's unset (a bye match only ever has
+// one side populated).
+func (s *Service) loadMatchParticipants(match *models.TournamentMatch) (a, b *models.TournamentParticipant, err error) {
+	if match.ParticipantAID != nil {
+		a = &models.TournamentParticipant{}
+		if err = s.db.First(a, "id = ?", *match.ParticipantAID).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+	if match.ParticipantBID != nil {
+		b = &models.TournamentParticipant{}
+		if err = s.db.First(b, "id = ?", *match.ParticipantBID).Error; err != nil {
+			return nil, nil, err
+		}
+	}
+	return a, b, nil
+}
+
+// scoresForMatch reads the PlayerScore rows recorded for match's
+// GameSession and keys them by TournamentParticipant ID (rather than
+// UserID), matching what AdvanceWinner expects.
+func (s *Service) scoresForMatch(match *models.TournamentMatch) (map[uuid.UUID]int, error) {
+	scoreByParticipant := make(map[uuid.UUID]int, 2)
+	if match.SessionID == nil {
+		return scoreByParticipant, nil
+	}
+
+	var scores []models.PlayerScore
+	if err := s.db.Where("session_id = ?", *match.SessionID).Find(&scores).Error; err != nil {
+		return nil, err
+	}
+	scoreByUser := make(map[uuid.UUID]int, len(scores))
+	for _, sc := range scores {
+		scoreByUser[sc.UserID] = sc.Score
+	}
+
+	var participants []models.TournamentParticipant
+	ids := make([]uuid.UUID, 0, 2)
+	if match.ParticipantAID != nil {
+		ids = append(ids, *match.ParticipantAID)
+	}
+	if match.ParticipantBID != nil {
+		ids = append(ids, *match.ParticipantBID)
+	}
+	if len(ids) == 0 {
+		return scoreByParticipant, nil
+	}
+	if err := s.db.Where("id IN ?", ids).Find(&participants).Error; err != nil {
+		return nil, err
+	}
+	for _, p := range participants {
+		scoreByParticipant[p.ID] = scoreByUser[p.UserID]
+	}
+	return scoreByParticipant, nil
+}
+
+func (s *Service) finishTournament(c *gin.Context, tournamentID uuid.UUID, winnerID *uuid.UUID) {
+	err := s.db.Model(&models.Tournament{}).Where("id = ?", tournamentID).
+		Update("status", models.TournamentStatusFinished).Error
+	if err != nil {
+		utils.InternalError(c, "Failed to finish tournament", err.Error())
+		return
+	}
+	c.JSON(http.StatusOK, utils.APIResponse{
+		Success: true,
+		Data:    gin.H{"status": models.TournamentStatusFinished, "winner_id": winnerID},
+	})
+}
+
+// Standings returns every participant in a tournament ordered by seed, with
+// eliminated participants flagged so clients can render a live bracket.
+func (s *Service) Standings(c *gin.Context) {
+	tournamentID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		utils.BadRequest(c, "Invalid tournament id")
+		return
+	}
+
+	var participants []models.TournamentParticipant
+	err = s.db.Where("tournament_id = ?", tournamentID).Order("seed").Find(&participants).Error
+	if err != nil {
+		utils.InternalError(c, "Failed to load standings", err.Error())
+		return
+	}
+
+	utils.Success(c, participants)
+}
@@ -104,6 +104,17 @@ func NotFound(c *gin.Context, message string) {
 	})
 }
 
+// TooManyRequests sends a 429 Too Many Requests response
+func TooManyRequests(c *gin.Context, message string) {
+	c.JSON(http.StatusTooManyRequests, APIResponse{
+		Success: false,
+		Error: &APIError{
+			Code:    "RATE_LIMITED",
+			Message: message,
+		},
+	})
+}
+
 // Conflict sends a 409 Conflict response
 func Conflict(c *gin.Context, message string) {
 	c.JSON(http.StatusConflict, APIResponse{
@@ -3,9 +3,14 @@ package main
 import (
 	"context"
 	"encoding/json"
+	"fmt"
+	"hash/fnv"
 	"log"
 	"net/http"
 	"os"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -13,61 +18,131 @@ import (
 	"github.com/gorilla/websocket"
 	"github.com/google/uuid"
 	"github.com/joho/godotenv"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+	"multimodal-platform/shared/ratelimit"
+	"multimodal-platform/shared/utils"
+	"multimodal-platform/websocket-service/sensorpb"
 )
 
 var (
 	upgrader = websocket.Upgrader{
-		CheckOrigin: func(r *http.Request) bool {
-			// TODO: Implement proper origin checking
-			return true
-		},
+		CheckOrigin:  checkOrigin,
+		Subprotocols: []string{binarySensorSubprotocol},
 	}
-	
+
 	redisClient *redis.Client
 	hub         *Hub
+	spectators  *SpectatorBroker
+
+	// db is this instance's Postgres connection, used only for best-effort
+	// bullet-chat persistence (see SpectatorBroker.persistBullet). It stays
+	// nil, with persistence skipped, when DATABASE_URL isn't set -- the
+	// live hub and spectating paths have no other dependency on it.
+	db *gorm.DB
+
+	// instanceID tags messages this process originates so the Redis
+	// subscriber can recognize and skip its own broadcasts.
+	instanceID = uuid.NewString()
+
+	// allowedOrigins is populated from WS_ALLOWED_ORIGINS at startup.
+	allowedOrigins map[string]bool
+
+	// wsHMACSecret signs and verifies the handshake token from WS_HMAC_SECRET.
+	wsHMACSecret string
+
+	limiter *ratelimit.Limiter
+
+	// perTypeLimit is the max per-user messages/sec for each inbound message
+	// type, overridable via RATE_LIMIT_<TYPE>_PER_SEC (e.g. RATE_LIMIT_SENSOR_DATA_PER_SEC).
+	perTypeLimit = map[string]int{
+		"sensor_data":  30,
+		"chat_message": 5,
+		"game_action":  10,
+	}
+
+	// roomBroadcastLimit caps aggregate broadcasts per room per second,
+	// overridable via RATE_LIMIT_ROOM_BROADCAST_PER_SEC.
+	roomBroadcastLimit = 100
 )
 
-// Hub maintains the set of active clients and broadcasts messages to the clients
-type Hub struct {
-	// Registered clients
-	clients map[*Client]bool
-	
-	// Inbound messages from the clients
-	broadcast chan []byte
-	
-	// Register requests from the clients
-	register chan *Client
-	
-	// Unregister requests from clients
-	unregister chan *Client
-	
-	// Room-based message routing
+// roomShardCount controls how many independently-locked shards Hub.rooms is
+// split into. Each room hashes to exactly one shard, so unrelated rooms
+// never contend on the same mutex.
+const roomShardCount = 32
+
+// roomShard holds one slice of the room->clients map behind its own lock.
+type roomShard struct {
+	mu    sync.RWMutex
 	rooms map[string]map[*Client]bool
 }
 
+// Hub maintains the set of active clients and broadcasts messages to the
+// clients. There's no longer a single goroutine serializing access to it:
+// clients is guarded by clientsMu, and rooms is sharded across shards so
+// register/unregister/broadcast calls from many client goroutines only
+// contend when they land on the same shard.
+type Hub struct {
+	clientsMu sync.RWMutex
+	clients   map[*Client]bool
+
+	shards [roomShardCount]*roomShard
+}
+
+func newHub() *Hub {
+	h := &Hub{clients: make(map[*Client]bool)}
+	for i := range h.shards {
+		h.shards[i] = &roomShard{rooms: make(map[string]map[*Client]bool)}
+	}
+	return h
+}
+
+// shardFor returns the shard responsible for roomID.
+func (h *Hub) shardFor(roomID string) *roomShard {
+	sum := fnv.New32a()
+	sum.Write([]byte(roomID))
+	return h.shards[sum.Sum32()%roomShardCount]
+}
+
 // Client is a middleman between the websocket connection and the hub
 type Client struct {
 	hub *Hub
-	
+
 	// The websocket connection
 	conn *websocket.Conn
-	
+
 	// Buffered channel of outbound messages
-	send chan []byte
-	
+	send      chan []byte
+	closeOnce sync.Once
+
 	// User information
 	userID   string
 	username string
 	roomID   string
+
+	// binaryMode is true when the client negotiated the binarySensorSubprotocol,
+	// in which case sensor_data frames are protobuf-wire SensorUpdate/SensorBatch
+	// messages instead of JSON Message envelopes.
+	binaryMode bool
+}
+
+// close closes the client's send channel at most once, so both Unregister
+// and dropFromRoom (reached when a broadcast finds the client's buffer
+// full) can try to tear it down without a double-close panic.
+func (c *Client) close() {
+	c.closeOnce.Do(func() {
+		close(c.send)
+	})
 }
 
 // Message represents a WebSocket message
 type Message struct {
-	Type      string      `json:"type"`
-	Data      interface{} `json:"data"`
-	UserID    string      `json:"user_id,omitempty"`
-	RoomID    string      `json:"room_id,omitempty"`
-	Timestamp int64       `json:"timestamp"`
+	Type       string      `json:"type"`
+	Data       interface{} `json:"data"`
+	UserID     string      `json:"user_id,omitempty"`
+	RoomID     string      `json:"room_id,omitempty"`
+	Timestamp  int64       `json:"timestamp"`
+	InstanceID string      `json:"instance_id,omitempty"`
 }
 
 // SensorUpdate represents real-time sensor data
@@ -88,6 +163,19 @@ func main() {
 		log.Println("No .env file found")
 	}
 
+	wsHMACSecret = os.Getenv("WS_HMAC_SECRET")
+	if wsHMACSecret == "" {
+		log.Println("WARNING: WS_HMAC_SECRET is not set; handshake tokens cannot be verified")
+	}
+
+	allowedOrigins = make(map[string]bool)
+	for _, origin := range strings.Split(os.Getenv("WS_ALLOWED_ORIGINS"), ",") {
+		origin = strings.TrimSpace(origin)
+		if origin != "" {
+			allowedOrigins[origin] = true
+		}
+	}
+
 	// Initialize Redis client
 	redisAddr := os.Getenv("REDIS_ADDR")
 	if redisAddr == "" {
@@ -108,17 +196,35 @@ func main() {
 		log.Println("Connected to Redis")
 	}
 
-	// Initialize hub
-	hub = &Hub{
-		broadcast:  make(chan []byte),
-		register:   make(chan *Client),
-		unregister: make(chan *Client),
-		clients:    make(map[*Client]bool),
-		rooms:      make(map[string]map[*Client]bool),
+	limiter = ratelimit.New(redisClient)
+	for msgType := range perTypeLimit {
+		envKey := "RATE_LIMIT_" + strings.ToUpper(msgType) + "_PER_SEC"
+		perTypeLimit[msgType] = envInt(envKey, perTypeLimit[msgType])
 	}
-	
-	// Start the hub
-	go hub.run()
+	roomBroadcastLimit = envInt("RATE_LIMIT_ROOM_BROADCAST_PER_SEC", roomBroadcastLimit)
+
+	// Connect to Postgres for bullet-chat persistence. This is optional:
+	// live spectating and gameplay never touch db, only replay does, so a
+	// missing DATABASE_URL just means bullet messages won't be replayable.
+	if dsn := os.Getenv("DATABASE_URL"); dsn != "" {
+		var err error
+		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+		if err != nil {
+			log.Printf("Database connection failed: %v", err)
+		} else {
+			log.Println("Connected to database")
+		}
+	} else {
+		log.Println("WARNING: DATABASE_URL is not set; bullet messages won't be persisted for replay")
+	}
+
+	// Initialize hub
+	hub = newHub()
+	spectators = newSpectatorBroker(db)
+
+	// Subscribe to other instances' room broadcasts so clients connected
+	// to this instance see messages published by peers.
+	go hub.runRedisSubscriber(context.Background())
 
 	// Set up Gin router
 	r := gin.Default()
@@ -134,16 +240,38 @@ func main() {
 	
 	// WebSocket endpoint
 	r.GET("/ws", handleWebSocket)
-	
-	// Room metrics endpoint
+
+	// Spectator endpoint: read-only synchronized viewing of a live room,
+	// with a bullet-chat overlay channel layered on top.
+	r.GET("/ws/spectate/:roomId", handleSpectate)
+
+	// Room metrics endpoint. Presence is tracked in Redis so this
+	// aggregates connection counts across every instance, not just
+	// the clients attached to this process.
 	r.GET("/metrics/rooms", func(c *gin.Context) {
+		ctx := context.Background()
 		stats := make(map[string]int)
-		for roomID, clients := range hub.rooms {
-			stats[roomID] = len(clients)
+		total := 0
+
+		iter := redisClient.Scan(ctx, 0, "room_presence:*", 0).Iterator()
+		for iter.Next(ctx) {
+			key := iter.Val()
+			roomID := strings.TrimPrefix(key, "room_presence:")
+			count, err := redisClient.SCard(ctx, key).Result()
+			if err != nil {
+				log.Printf("Error reading room presence for %s: %v", roomID, err)
+				continue
+			}
+			stats[roomID] = int(count)
+			total += int(count)
+		}
+		if err := iter.Err(); err != nil {
+			log.Printf("Error scanning room presence: %v", err)
 		}
+
 		c.JSON(http.StatusOK, gin.H{
-			"rooms": stats,
-			"total_connections": len(hub.clients),
+			"rooms":             stats,
+			"total_connections": total,
 		})
 	})
 
@@ -156,166 +284,349 @@ func main() {
 	log.Fatal(r.Run(":" + port))
 }
 
-func (h *Hub) run() {
-	for {
-		select {
-		case client := <-h.register:
-			h.clients[client] = true
-			
-			// Add to room
-			if client.roomID != "" {
-				if h.rooms[client.roomID] == nil {
-					h.rooms[client.roomID] = make(map[*Client]bool)
-				}
-				h.rooms[client.roomID][client] = true
-				
-				// Notify room about new user
-				h.broadcastToRoom(client.roomID, Message{
-					Type:   "user_joined",
-					UserID: client.userID,
-					RoomID: client.roomID,
-					Data: map[string]string{
-						"username": client.username,
-					},
-					Timestamp: time.Now().Unix(),
-				})
-				
-				// Update Redis with room state
-				h.updateRoomStateInRedis(client.roomID)
-			}
-			
-			log.Printf("Client registered: %s in room %s", client.userID, client.roomID)
-
-		case client := <-h.unregister:
-			if _, ok := h.clients[client]; ok {
-				delete(h.clients, client)
-				close(client.send)
-				
-				// Remove from room
-				if client.roomID != "" && h.rooms[client.roomID] != nil {
-					delete(h.rooms[client.roomID], client)
-					
-					// Clean up empty rooms
-					if len(h.rooms[client.roomID]) == 0 {
-						delete(h.rooms, client.roomID)
-					} else {
-						// Notify room about user leaving
-						h.broadcastToRoom(client.roomID, Message{
-							Type:   "user_left",
-							UserID: client.userID,
-							RoomID: client.roomID,
-							Data: map[string]string{
-								"username": client.username,
-							},
-							Timestamp: time.Now().Unix(),
-						})
-					}
-					
-					// Update Redis
-					h.updateRoomStateInRedis(client.roomID)
-				}
-				
-				log.Printf("Client unregistered: %s", client.userID)
-			}
+// Register adds client to the hub and, if it joined with a room, to that
+// room's shard, then announces it to the room.
+func (h *Hub) Register(client *Client) {
+	h.clientsMu.Lock()
+	h.clients[client] = true
+	h.clientsMu.Unlock()
 
-		case message := <-h.broadcast:
-			// Broadcast to all clients (rarely used)
-			for client := range h.clients {
-				select {
-				case client.send <- message:
-				default:
-					close(client.send)
-					delete(h.clients, client)
-				}
+	if client.roomID != "" {
+		shard := h.shardFor(client.roomID)
+		shard.mu.Lock()
+		if shard.rooms[client.roomID] == nil {
+			shard.rooms[client.roomID] = make(map[*Client]bool)
+		}
+		shard.rooms[client.roomID][client] = true
+		shard.mu.Unlock()
+
+		h.broadcastToRoom(client.roomID, Message{
+			Type:   "user_joined",
+			UserID: client.userID,
+			RoomID: client.roomID,
+			Data: map[string]string{
+				"username": client.username,
+			},
+			Timestamp: time.Now().Unix(),
+		})
+
+		h.updateRoomStateInRedis(client.roomID)
+		h.addPresence(client)
+	}
+
+	log.Printf("Client registered: %s in room %s", client.userID, client.roomID)
+}
+
+// Unregister removes client from the hub and its room, closing its send
+// channel and announcing the departure if the room still has members.
+func (h *Hub) Unregister(client *Client) {
+	h.clientsMu.Lock()
+	if _, ok := h.clients[client]; !ok {
+		h.clientsMu.Unlock()
+		return
+	}
+	delete(h.clients, client)
+	h.clientsMu.Unlock()
+	client.close()
+
+	if client.roomID != "" {
+		shard := h.shardFor(client.roomID)
+		shard.mu.Lock()
+		roomStillHasMembers := false
+		if clients := shard.rooms[client.roomID]; clients != nil {
+			delete(clients, client)
+			if len(clients) == 0 {
+				delete(shard.rooms, client.roomID)
+			} else {
+				roomStillHasMembers = true
 			}
 		}
+		shard.mu.Unlock()
+
+		if roomStillHasMembers {
+			h.broadcastToRoom(client.roomID, Message{
+				Type:   "user_left",
+				UserID: client.userID,
+				RoomID: client.roomID,
+				Data: map[string]string{
+					"username": client.username,
+				},
+				Timestamp: time.Now().Unix(),
+			})
+		}
+
+		h.updateRoomStateInRedis(client.roomID)
+		h.removePresence(client)
+	}
+
+	log.Printf("Client unregistered: %s", client.userID)
+}
+
+// envInt reads an integer environment variable, falling back to def when
+// unset or unparsable.
+func envInt(key string, def int) int {
+	raw := os.Getenv(key)
+	if raw == "" {
+		return def
+	}
+	v, err := strconv.Atoi(raw)
+	if err != nil {
+		return def
+	}
+	return v
+}
+
+// roomMembers returns a snapshot of roomID's current clients, taken under
+// the shard's read lock so callers never range over the live map.
+func (h *Hub) roomMembers(roomID string) []*Client {
+	shard := h.shardFor(roomID)
+	shard.mu.RLock()
+	defer shard.mu.RUnlock()
+
+	clients := shard.rooms[roomID]
+	if len(clients) == 0 {
+		return nil
+	}
+	members := make([]*Client, 0, len(clients))
+	for client := range clients {
+		members = append(members, client)
+	}
+	return members
+}
+
+// dropFromRoom removes dead clients (ones whose send buffer was full) from
+// roomID and from the hub's client set.
+func (h *Hub) dropFromRoom(roomID string, dead []*Client) {
+	if len(dead) == 0 {
+		return
+	}
+
+	shard := h.shardFor(roomID)
+	shard.mu.Lock()
+	if clients := shard.rooms[roomID]; clients != nil {
+		for _, client := range dead {
+			delete(clients, client)
+		}
+		if len(clients) == 0 {
+			delete(shard.rooms, roomID)
+		}
+	}
+	shard.mu.Unlock()
+
+	h.clientsMu.Lock()
+	for _, client := range dead {
+		delete(h.clients, client)
+	}
+	h.clientsMu.Unlock()
+
+	for _, client := range dead {
+		client.close()
 	}
 }
 
 func (h *Hub) broadcastToRoom(roomID string, message Message) {
-	if h.rooms[roomID] == nil {
+	members := h.roomMembers(roomID)
+	if members == nil {
 		return
 	}
-	
+
+	if allowed, _, err := limiter.Allow(context.Background(), "room_broadcast", roomID, roomBroadcastLimit, time.Second); err != nil {
+		log.Printf("Rate limiter error for room %s: %v", roomID, err)
+	} else if !allowed {
+		return
+	}
+
+	// Tag with our instance ID so the Redis subscriber on every instance
+	// (including this one) can tell the message originated here and skip
+	// re-delivering it when it comes back over the "room:*" channel.
+	message.InstanceID = instanceID
+
 	messageBytes, err := json.Marshal(message)
 	if err != nil {
 		log.Printf("Error marshaling message: %v", err)
 		return
 	}
-	
-	for client := range h.rooms[roomID] {
+
+	var dead []*Client
+	for _, client := range members {
 		select {
 		case client.send <- messageBytes:
 		default:
-			close(client.send)
-			delete(h.clients, client)
-			delete(h.rooms[roomID], client)
+			dead = append(dead, client)
 		}
 	}
-	
+	h.dropFromRoom(roomID, dead)
+
 	// Also publish to Redis for other service instances
 	ctx := context.Background()
 	redisClient.Publish(ctx, "room:"+roomID, messageBytes)
 }
 
 func (h *Hub) updateRoomStateInRedis(roomID string) {
-	if h.rooms[roomID] == nil {
+	members := h.roomMembers(roomID)
+	if members == nil {
 		return
 	}
-	
-	ctx := context.Background()
-	participants := make([]string, 0)
-	
-	for client := range h.rooms[roomID] {
+
+	participants := make([]string, 0, len(members))
+	for _, client := range members {
 		participants = append(participants, client.userID)
 	}
-	
+
 	roomState := map[string]interface{}{
 		"participants": participants,
-		"count":       len(participants),
-		"updated_at":  time.Now().Unix(),
+		"count":        len(participants),
+		"updated_at":   time.Now().Unix(),
 	}
-	
+
 	data, err := json.Marshal(roomState)
 	if err != nil {
 		log.Printf("Error marshaling room state: %v", err)
 		return
 	}
-	
+
+	ctx := context.Background()
 	redisClient.Set(ctx, "room_state:"+roomID, data, time.Hour)
 }
 
+// addPresence and removePresence maintain a Redis set of the userIDs
+// connected to roomID across all instances, so /metrics/rooms can report
+// a cluster-wide count rather than just this process's local clients.
+func (h *Hub) addPresence(client *Client) {
+	ctx := context.Background()
+	redisClient.SAdd(ctx, "room_presence:"+client.roomID, client.userID)
+}
+
+func (h *Hub) removePresence(client *Client) {
+	if client.roomID == "" {
+		return
+	}
+	ctx := context.Background()
+	redisClient.SRem(ctx, "room_presence:"+client.roomID, client.userID)
+}
+
+// runRedisSubscriber listens for room broadcasts published by every
+// instance (including this one) and delivers the ones we didn't originate
+// to our local room members, giving clients on different instances a
+// shared view of the room. It deliberately does not subscribe to
+// "game_actions:*" -- that channel is handleGameAction's feed for the
+// (not yet built) game service, not a hub-to-hub fan-out; subscribing to
+// it here would additionally deliver every game action a second time,
+// since broadcastToRoom already publishes it to "room:*".
+func (h *Hub) runRedisSubscriber(ctx context.Context) {
+	pubsub := redisClient.PSubscribe(ctx, "room:*")
+	defer pubsub.Close()
+
+	for redisMsg := range pubsub.Channel() {
+		h.handleFederatedMessage(redisMsg)
+	}
+}
+
+func (h *Hub) handleFederatedMessage(redisMsg *redis.Message) {
+	var roomID string
+	switch {
+	case strings.HasPrefix(redisMsg.Channel, "room:"):
+		roomID = strings.TrimPrefix(redisMsg.Channel, "room:")
+	default:
+		return
+	}
+
+	var msg Message
+	if err := json.Unmarshal([]byte(redisMsg.Payload), &msg); err != nil {
+		log.Printf("Error decoding federated message: %v", err)
+		return
+	}
+
+	// We already delivered this to our local clients when we published it.
+	if msg.InstanceID == instanceID {
+		return
+	}
+
+	members := h.roomMembers(roomID)
+	if members == nil {
+		return
+	}
+
+	messageBytes := []byte(redisMsg.Payload)
+	var dead []*Client
+	for _, client := range members {
+		select {
+		case client.send <- messageBytes:
+		default:
+			dead = append(dead, client)
+		}
+	}
+	h.dropFromRoom(roomID, dead)
+}
+
+// checkOrigin enforces WS_ALLOWED_ORIGINS. With no allowlist configured it
+// falls back to permissive (useful for local dev); non-browser clients that
+// omit Origin altogether are always allowed through since the handshake
+// token is what actually authorizes the connection.
+func checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" || len(allowedOrigins) == 0 {
+		return true
+	}
+	return allowedOrigins[origin]
+}
+
+// handshakeToken extracts the signed token from either the `token` query
+// param or the Sec-WebSocket-Protocol header, which browser WebSocket
+// clients can set without custom headers. binarySensorSubprotocol is a
+// protocol name, not a token, so it's skipped when scanning the header.
+func handshakeToken(c *gin.Context) string {
+	if token := c.Query("token"); token != "" {
+		return token
+	}
+	for _, p := range websocket.Subprotocols(c.Request) {
+		if p != binarySensorSubprotocol {
+			return p
+		}
+	}
+	return ""
+}
+
 func handleWebSocket(c *gin.Context) {
-	// Get user info from query params or headers
-	userID := c.Query("user_id")
-	username := c.Query("username")
-	roomID := c.Query("room_id")
-	
-	if userID == "" || roomID == "" {
+	token := handshakeToken(c)
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing handshake token"})
+		return
+	}
+
+	claims, err := utils.VerifyWSToken(token, wsHMACSecret)
+	if err != nil {
+		log.Printf("WebSocket handshake rejected: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+		return
+	}
+
+	if claims.UserID == "" || claims.RoomID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "user_id and room_id are required"})
 		return
 	}
-	
+
 	// Upgrade HTTP connection to WebSocket
 	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
 	if err != nil {
 		log.Printf("WebSocket upgrade failed: %v", err)
 		return
 	}
-	
-	// Create new client
+
+	// Create new client from the verified claims, not raw query params
 	client := &Client{
-		hub:      hub,
-		conn:     conn,
-		send:     make(chan []byte, 256),
-		userID:   userID,
-		username: username,
-		roomID:   roomID,
+		hub:        hub,
+		conn:       conn,
+		send:       make(chan []byte, 256),
+		userID:     claims.UserID,
+		username:   claims.Username,
+		roomID:     claims.RoomID,
+		binaryMode: conn.Subprotocol() == binarySensorSubprotocol,
 	}
-	
+
 	// Register client
-	client.hub.register <- client
-	
+	client.hub.Register(client)
+
 	// Start goroutines for reading and writing
 	go client.writePump()
 	go client.readPump()
@@ -333,11 +644,15 @@ const (
 	
 	// Maximum message size allowed from peer
 	maxMessageSize = 512
+
+	// binarySensorSubprotocol is the Sec-WebSocket-Protocol value clients
+	// offer to switch sensor_data frames from JSON to protobuf-wire binary.
+	binarySensorSubprotocol = "sensor.v1.pb"
 )
 
 func (c *Client) readPump() {
 	defer func() {
-		c.hub.unregister <- c
+		c.hub.Unregister(c)
 		c.conn.Close()
 	}()
 	
@@ -349,26 +664,44 @@ func (c *Client) readPump() {
 	})
 	
 	for {
-		_, messageBytes, err := c.conn.ReadMessage()
+		frameType, messageBytes, err := c.conn.ReadMessage()
 		if err != nil {
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseGoingAway, websocket.CloseAbnormalClosure) {
 				log.Printf("WebSocket error: %v", err)
 			}
 			break
 		}
-		
-		// Parse message
+
 		var msg Message
-		if err := json.Unmarshal(messageBytes, &msg); err != nil {
+		if frameType == websocket.BinaryMessage {
+			// Binary frames only ever carry a single sensor_data reading;
+			// batching is a write-side optimization (see writePump).
+			update, err := sensorpb.UnmarshalSensorUpdate(messageBytes)
+			if err != nil {
+				log.Printf("Error decoding binary sensor update: %v", err)
+				continue
+			}
+			msg = Message{Type: "sensor_data", Data: update}
+		} else if err := json.Unmarshal(messageBytes, &msg); err != nil {
 			log.Printf("Error parsing message: %v", err)
 			continue
 		}
-		
+
 		// Set message metadata
 		msg.UserID = c.userID
 		msg.RoomID = c.roomID
 		msg.Timestamp = time.Now().Unix()
-		
+
+		if limit, ok := perTypeLimit[msg.Type]; ok {
+			allowed, _, err := limiter.Allow(context.Background(), "ws:"+msg.Type, c.userID, limit, time.Second)
+			if err != nil {
+				log.Printf("Rate limiter error for %s: %v", msg.Type, err)
+			} else if !allowed {
+				// Drop the over-limit frame rather than disconnecting the client.
+				continue
+			}
+		}
+
 		// Handle different message types
 		switch msg.Type {
 		case "sensor_data":
@@ -401,24 +734,31 @@ func (c *Client) writePump() {
 				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
 				return
 			}
-			
+
+			if c.binaryMode {
+				if !c.writeQueuedMessages(message) {
+					return
+				}
+				continue
+			}
+
 			w, err := c.conn.NextWriter(websocket.TextMessage)
 			if err != nil {
 				return
 			}
 			w.Write(message)
-			
+
 			// Add queued messages to the current websocket message
 			n := len(c.send)
 			for i := 0; i < n; i++ {
 				w.Write([]byte{'\n'})
 				w.Write(<-c.send)
 			}
-			
+
 			if err := w.Close(); err != nil {
 				return
 			}
-			
+
 		case <-ticker.C:
 			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
 			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
@@ -428,10 +768,78 @@ func (c *Client) writePump() {
 	}
 }
 
+// writeQueuedMessages drains the send channel like the JSON path does, but
+// for a binary-mode client it packs every queued sensor_data update into a
+// single SensorBatch frame instead of newline-joining JSON blobs; any other
+// message type is still written as its own JSON text frame.
+func (c *Client) writeQueuedMessages(first []byte) bool {
+	queued := append([][]byte{first}, drainChannel(c.send)...)
+
+	batch := &sensorpb.SensorBatch{}
+	for _, raw := range queued {
+		var msg Message
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			log.Printf("Error parsing queued message: %v", err)
+			continue
+		}
+
+		if msg.Type != "sensor_data" {
+			if err := c.conn.WriteMessage(websocket.TextMessage, raw); err != nil {
+				return false
+			}
+			continue
+		}
+
+		update, err := sensorDataToUpdate(msg.Data)
+		if err != nil {
+			log.Printf("Error converting sensor data for binary frame: %v", err)
+			continue
+		}
+		batch.Updates = append(batch.Updates, update)
+	}
+
+	if len(batch.Updates) == 0 {
+		return true
+	}
+	return c.conn.WriteMessage(websocket.BinaryMessage, batch.Marshal()) == nil
+}
+
+func drainChannel(ch chan []byte) [][]byte {
+	n := len(ch)
+	drained := make([][]byte, 0, n)
+	for i := 0; i < n; i++ {
+		drained = append(drained, <-ch)
+	}
+	return drained
+}
+
+// sensorDataToUpdate normalizes msg.Data - a *sensorpb.SensorUpdate when it
+// came in over a binary frame, or a map[string]interface{} when it came in
+// as JSON - into a single SensorUpdate for batching.
+func sensorDataToUpdate(data interface{}) (*sensorpb.SensorUpdate, error) {
+	if update, ok := data.(*sensorpb.SensorUpdate); ok {
+		return update, nil
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return nil, err
+	}
+	var update sensorpb.SensorUpdate
+	if err := json.Unmarshal(raw, &update); err != nil {
+		return nil, err
+	}
+	return &update, nil
+}
+
 func (c *Client) handleSensorData(msg Message) {
 	// Broadcast sensor data to room participants
 	c.hub.broadcastToRoom(c.roomID, msg)
-	
+
+	// Relay to anyone spectating this room, stamped with the server's
+	// authoritative time so every spectator stays in sync.
+	spectators.BroadcastFrame(c.roomID, c.userID, msg.Data)
+
 	// Store in Redis for analytics
 	ctx := context.Background()
 	key := fmt.Sprintf("sensor:%s:%s", c.roomID, c.userID)
@@ -450,7 +858,8 @@ func (c *Client) handleGameAction(msg Message) {
 	// Forward game actions to game service via Redis pub/sub
 	ctx := context.Background()
 	channel := fmt.Sprintf("game_actions:%s", c.roomID)
-	
+	msg.InstanceID = instanceID
+
 	data, err := json.Marshal(msg)
 	if err != nil {
 		log.Printf("Error marshaling game action: %v", err)
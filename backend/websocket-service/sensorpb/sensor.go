@@ -0,0 +1,214 @@
+// Package sensorpb implements the wire format described by
+// proto/sensor.proto. It is hand-written rather than protoc-gen-go
+// generated output: this build has no protoc/protoc-gen-go available, so
+// actual codegen can't be wired up from here. Reviewed and accepted as
+// the interim approach -- it encodes/decodes the same tag-length-value
+// layout a real protobuf runtime would, so it stays a drop-in swap for
+// generated code once a protoc toolchain is added to the build. Keep this
+// file's message shapes in sync with proto/sensor.proto by hand until then.
+package sensorpb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+)
+
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+)
+
+// SensorUpdate mirrors proto/sensor.proto's SensorUpdate message.
+type SensorUpdate struct {
+	Type       string  `json:"type"`
+	Alpha      float64 `json:"alpha,omitempty"`
+	Beta       float64 `json:"beta,omitempty"`
+	Gamma      float64 `json:"gamma,omitempty"`
+	X          float64 `json:"x,omitempty"`
+	Y          float64 `json:"y,omitempty"`
+	Confidence float64 `json:"confidence,omitempty"`
+	Timestamp  int64   `json:"timestamp"`
+}
+
+// SensorBatch mirrors proto/sensor.proto's SensorBatch message.
+type SensorBatch struct {
+	Updates []*SensorUpdate
+}
+
+// Marshal encodes u as a protobuf-wire-compatible SensorUpdate message.
+func (u *SensorUpdate) Marshal() []byte {
+	var buf []byte
+	buf = putString(buf, 1, u.Type)
+	buf = putDouble(buf, 2, u.Alpha)
+	buf = putDouble(buf, 3, u.Beta)
+	buf = putDouble(buf, 4, u.Gamma)
+	buf = putDouble(buf, 5, u.X)
+	buf = putDouble(buf, 6, u.Y)
+	buf = putDouble(buf, 7, u.Confidence)
+	buf = putInt64(buf, 8, u.Timestamp)
+	return buf
+}
+
+// UnmarshalSensorUpdate decodes a message produced by SensorUpdate.Marshal.
+func UnmarshalSensorUpdate(data []byte) (*SensorUpdate, error) {
+	u := &SensorUpdate{}
+	for len(data) > 0 {
+		field, wireType, n, err := readTag(data)
+		if err != nil {
+			return nil, err
+		}
+		data = data[n:]
+
+		switch wireType {
+		case wireVarint:
+			v, n, err := readVarint(data)
+			if err != nil {
+				return nil, err
+			}
+			data = data[n:]
+			if field == 8 {
+				u.Timestamp = int64(v)
+			}
+
+		case wireFixed64:
+			if len(data) < 8 {
+				return nil, errors.New("sensorpb: truncated fixed64 field")
+			}
+			v := math.Float64frombits(binary.LittleEndian.Uint64(data[:8]))
+			data = data[8:]
+			switch field {
+			case 2:
+				u.Alpha = v
+			case 3:
+				u.Beta = v
+			case 4:
+				u.Gamma = v
+			case 5:
+				u.X = v
+			case 6:
+				u.Y = v
+			case 7:
+				u.Confidence = v
+			}
+
+		case wireBytes:
+			l, n, err := readVarint(data)
+			if err != nil {
+				return nil, err
+			}
+			data = data[n:]
+			if uint64(len(data)) < l {
+				return nil, errors.New("sensorpb: truncated bytes field")
+			}
+			if field == 1 {
+				u.Type = string(data[:l])
+			}
+			data = data[l:]
+
+		default:
+			return nil, fmt.Errorf("sensorpb: unsupported wire type %d", wireType)
+		}
+	}
+	return u, nil
+}
+
+// Marshal encodes b as a protobuf-wire-compatible SensorBatch message.
+func (b *SensorBatch) Marshal() []byte {
+	var buf []byte
+	for _, u := range b.Updates {
+		entry := u.Marshal()
+		buf = putTag(buf, 1, wireBytes)
+		buf = putVarint(buf, uint64(len(entry)))
+		buf = append(buf, entry...)
+	}
+	return buf
+}
+
+// UnmarshalSensorBatch decodes a message produced by SensorBatch.Marshal.
+func UnmarshalSensorBatch(data []byte) (*SensorBatch, error) {
+	batch := &SensorBatch{}
+	for len(data) > 0 {
+		field, wireType, n, err := readTag(data)
+		if err != nil {
+			return nil, err
+		}
+		data = data[n:]
+
+		if wireType != wireBytes || field != 1 {
+			return nil, fmt.Errorf("sensorpb: unexpected field %d wiretype %d in SensorBatch", field, wireType)
+		}
+
+		l, n, err := readVarint(data)
+		if err != nil {
+			return nil, err
+		}
+		data = data[n:]
+		if uint64(len(data)) < l {
+			return nil, errors.New("sensorpb: truncated batch entry")
+		}
+
+		update, err := UnmarshalSensorUpdate(data[:l])
+		if err != nil {
+			return nil, err
+		}
+		batch.Updates = append(batch.Updates, update)
+		data = data[l:]
+	}
+	return batch, nil
+}
+
+func putTag(buf []byte, field int, wireType int) []byte {
+	return putVarint(buf, uint64(field)<<3|uint64(wireType))
+}
+
+func putVarint(buf []byte, v uint64) []byte {
+	var tmp [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(tmp[:], v)
+	return append(buf, tmp[:n]...)
+}
+
+func putString(buf []byte, field int, s string) []byte {
+	if s == "" {
+		return buf
+	}
+	buf = putTag(buf, field, wireBytes)
+	buf = putVarint(buf, uint64(len(s)))
+	return append(buf, s...)
+}
+
+func putDouble(buf []byte, field int, v float64) []byte {
+	if v == 0 {
+		return buf
+	}
+	buf = putTag(buf, field, wireFixed64)
+	var tmp [8]byte
+	binary.LittleEndian.PutUint64(tmp[:], math.Float64bits(v))
+	return append(buf, tmp[:]...)
+}
+
+func putInt64(buf []byte, field int, v int64) []byte {
+	if v == 0 {
+		return buf
+	}
+	buf = putTag(buf, field, wireVarint)
+	return putVarint(buf, uint64(v))
+}
+
+func readVarint(data []byte) (uint64, int, error) {
+	v, n := binary.Uvarint(data)
+	if n <= 0 {
+		return 0, 0, errors.New("sensorpb: invalid varint")
+	}
+	return v, n, nil
+}
+
+func readTag(data []byte) (field int, wireType int, n int, err error) {
+	v, n, err := readVarint(data)
+	if err != nil {
+		return 0, 0, 0, err
+	}
+	return int(v >> 3), int(v & 0x7), n, nil
+}
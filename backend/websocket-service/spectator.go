@@ -0,0 +1,318 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+	"github.com/gorilla/websocket"
+	"gorm.io/gorm"
+	"multimodal-platform/shared/models"
+	"multimodal-platform/shared/utils"
+)
+
+// bulletMessageLimit caps bullet-chat overlay messages per user per minute,
+// enforced through the same Redis-backed limiter as every other inbound
+// message type.
+const bulletMessageLimit = 20
+
+// SpectatorBroker fans a live room's SensorData frames, stamped with a
+// server-authoritative timestamp, out to every non-player watching it,
+// along with a bullet-chat overlay channel. It never touches gameplay
+// state - handleSensorData just also hands frames to it. It does persist
+// bullet messages (best-effort, via db) so shared/spectator's replay
+// builder has BulletMessage rows to reconstruct later; db is nil, and
+// persistence skipped, when this instance has no DATABASE_URL configured.
+type SpectatorBroker struct {
+	mu    sync.RWMutex
+	rooms map[string]*spectatorRoom
+	db    *gorm.DB
+}
+
+func newSpectatorBroker(db *gorm.DB) *SpectatorBroker {
+	return &SpectatorBroker{rooms: make(map[string]*spectatorRoom), db: db}
+}
+
+// spectatorRoom holds every spectator watching one room.
+type spectatorRoom struct {
+	mu      sync.RWMutex
+	clients map[*SpectatorClient]bool
+}
+
+// SpectatorClient is one spectator's read-mostly WebSocket connection: it
+// receives sensor frames and bullet overlays, and may only send bullet
+// messages back.
+type SpectatorClient struct {
+	conn      *websocket.Conn
+	send      chan []byte
+	closeOnce sync.Once
+	roomID    string
+	userID    string
+}
+
+func (c *SpectatorClient) close() {
+	c.closeOnce.Do(func() { close(c.send) })
+}
+
+// SpectatorFrame is a server-timestamped SensorData update relayed to
+// spectators of a live room.
+type SpectatorFrame struct {
+	Type       string      `json:"type"`
+	RoomID     string      `json:"room_id"`
+	UserID     string      `json:"user_id"`
+	Data       interface{} `json:"data"`
+	ServerTime int64       `json:"server_time"` // unix nanos; the sync clock clients render against
+}
+
+// BulletMessage is a rate-limited chat overlay that scrolls across a
+// spectator's view like danmaku, carrying the position, color, and
+// on-screen duration clients need to render it.
+type BulletMessage struct {
+	Type       string  `json:"type"`
+	RoomID     string  `json:"room_id"`
+	UserID     string  `json:"user_id"`
+	Text       string  `json:"text"`
+	X          float64 `json:"x"`
+	Y          float64 `json:"y"`
+	Color      string  `json:"color"`
+	Duration   int     `json:"duration"` // milliseconds on screen
+	ServerTime int64   `json:"server_time"`
+}
+
+func (b *SpectatorBroker) room(roomID string) *spectatorRoom {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	r, ok := b.rooms[roomID]
+	if !ok {
+		r = &spectatorRoom{clients: make(map[*SpectatorClient]bool)}
+		b.rooms[roomID] = r
+	}
+	return r
+}
+
+func (b *SpectatorBroker) join(client *SpectatorClient) {
+	room := b.room(client.roomID)
+	room.mu.Lock()
+	room.clients[client] = true
+	room.mu.Unlock()
+}
+
+func (b *SpectatorBroker) leave(client *SpectatorClient) {
+	b.mu.RLock()
+	room, ok := b.rooms[client.roomID]
+	b.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	room.mu.Lock()
+	delete(room.clients, client)
+	empty := len(room.clients) == 0
+	room.mu.Unlock()
+	client.close()
+
+	if empty {
+		b.mu.Lock()
+		delete(b.rooms, client.roomID)
+		b.mu.Unlock()
+	}
+}
+
+// BroadcastFrame fans a live SensorData update out to every spectator of
+// roomID, stamping it with the server's current time.
+func (b *SpectatorBroker) BroadcastFrame(roomID, userID string, data interface{}) {
+	b.broadcastJSON(roomID, SpectatorFrame{
+		Type:       "sensor_frame",
+		RoomID:     roomID,
+		UserID:     userID,
+		Data:       data,
+		ServerTime: time.Now().UnixNano(),
+	})
+}
+
+// BroadcastBullet rate-limits a bullet message per user, then fans it out
+// to every spectator of msg.RoomID.
+func (b *SpectatorBroker) BroadcastBullet(msg BulletMessage) error {
+	if limiter != nil {
+		allowed, _, err := limiter.Allow(context.Background(), "bullet_message", msg.UserID, bulletMessageLimit, time.Minute)
+		if err != nil {
+			log.Printf("Bullet message rate limit check failed: %v", err)
+		} else if !allowed {
+			return fmt.Errorf("bullet message rate limit exceeded for user %s", msg.UserID)
+		}
+	}
+
+	msg.Type = "bullet_message"
+	msg.ServerTime = time.Now().UnixNano()
+	b.broadcastJSON(msg.RoomID, msg)
+
+	if b.db != nil {
+		if err := b.persistBullet(msg); err != nil {
+			log.Printf("Failed to persist bullet message for replay: %v", err)
+		}
+	}
+	return nil
+}
+
+// persistBullet records msg as a models.BulletMessage row against the
+// room's current GameSession, so shared/spectator's Replay.Build can
+// reconstruct the same overlay later. It's best-effort: a lookup or
+// write failure here must never block the live broadcast above.
+func (b *SpectatorBroker) persistBullet(msg BulletMessage) error {
+	roomID, err := uuid.Parse(msg.RoomID)
+	if err != nil {
+		return err
+	}
+	userID, err := uuid.Parse(msg.UserID)
+	if err != nil {
+		return err
+	}
+
+	var session models.GameSession
+	if err := b.db.Where("room_id = ?", roomID).Order("created_at desc").First(&session).Error; err != nil {
+		return err
+	}
+
+	return b.db.Create(&models.BulletMessage{
+		SessionID: session.ID,
+		UserID:    userID,
+		Text:      msg.Text,
+		X:         msg.X,
+		Y:         msg.Y,
+		Color:     msg.Color,
+		Duration:  msg.Duration,
+	}).Error
+}
+
+func (b *SpectatorBroker) broadcastJSON(roomID string, payload interface{}) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf("Error marshaling spectator payload: %v", err)
+		return
+	}
+
+	b.mu.RLock()
+	room, ok := b.rooms[roomID]
+	b.mu.RUnlock()
+	if !ok {
+		return
+	}
+
+	room.mu.RLock()
+	defer room.mu.RUnlock()
+	for client := range room.clients {
+		select {
+		case client.send <- data:
+		default:
+			log.Printf("Dropping spectator frame: client %s send buffer full", client.userID)
+		}
+	}
+}
+
+// handleSpectate upgrades a request to a WebSocket and subscribes it to a
+// room's live spectator feed. It's read-mostly, but bullet-chat messages
+// are stamped with the spectator's identity, so the same signed
+// handshake token the main /ws handshake requires is required here too -
+// a bare query-param user_id would let any spectator impersonate anyone.
+func handleSpectate(c *gin.Context) {
+	roomID := c.Param("roomId")
+	if roomID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing room id"})
+		return
+	}
+
+	token := handshakeToken(c)
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing handshake token"})
+		return
+	}
+	claims, err := utils.VerifyWSToken(token, wsHMACSecret)
+	if err != nil {
+		log.Printf("Spectator handshake rejected: %v", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid or expired token"})
+		return
+	}
+	if claims.RoomID != roomID {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "token is not valid for this room"})
+		return
+	}
+
+	conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+	if err != nil {
+		log.Printf("Spectator upgrade failed: %v", err)
+		return
+	}
+
+	client := &SpectatorClient{
+		conn:   conn,
+		send:   make(chan []byte, 256),
+		roomID: roomID,
+		userID: claims.UserID,
+	}
+	spectators.join(client)
+
+	go client.writePump()
+	client.readPump()
+}
+
+func (c *SpectatorClient) readPump() {
+	defer spectators.leave(c)
+	c.conn.SetReadLimit(maxMessageSize)
+	c.conn.SetReadDeadline(time.Now().Add(pongWait))
+	c.conn.SetPongHandler(func(string) error {
+		c.conn.SetReadDeadline(time.Now().Add(pongWait))
+		return nil
+	})
+
+	for {
+		_, raw, err := c.conn.ReadMessage()
+		if err != nil {
+			break
+		}
+
+		var msg BulletMessage
+		if err := json.Unmarshal(raw, &msg); err != nil {
+			log.Printf("Error parsing bullet message: %v", err)
+			continue
+		}
+		msg.RoomID = c.roomID
+		msg.UserID = c.userID
+		if err := spectators.BroadcastBullet(msg); err != nil {
+			log.Printf("Bullet message dropped: %v", err)
+		}
+	}
+}
+
+func (c *SpectatorClient) writePump() {
+	ticker := time.NewTicker(pingPeriod)
+	defer func() {
+		ticker.Stop()
+		c.conn.Close()
+	}()
+
+	for {
+		select {
+		case message, ok := <-c.send:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if !ok {
+				c.conn.WriteMessage(websocket.CloseMessage, []byte{})
+				return
+			}
+			if err := c.conn.WriteMessage(websocket.TextMessage, message); err != nil {
+				return
+			}
+
+		case <-ticker.C:
+			c.conn.SetWriteDeadline(time.Now().Add(writeWait))
+			if err := c.conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				return
+			}
+		}
+	}
+}